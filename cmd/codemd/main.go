@@ -4,14 +4,26 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"path/filepath"
 
 	"github.com/kihyun1998/codemd/internal/config"
 	"github.com/kihyun1998/codemd/internal/generator"
 	"github.com/kihyun1998/codemd/internal/parser"
+	"github.com/kihyun1998/codemd/internal/structure"
 	"github.com/kihyun1998/codemd/internal/version"
 )
 
+// exitSkipped는 -keep-going으로 파일을 건너뛰고 생성을 마쳤을 때의 종료 코드
+// (log.Fatal 에러는 1, 잘못된 플래그는 2)
+const exitSkipped = 3
+
 func main() {
+	// check-ignore 서브커맨드
+	if len(os.Args) > 1 && os.Args[1] == "check-ignore" {
+		checkIgnore(os.Args[2:])
+		return
+	}
+
 	// 커스텀 usage 메시지 설정
 	config.SetUsage(os.Args[0])
 
@@ -29,34 +41,129 @@ func main() {
 
 	// 파서 생성
 	dirParser := parser.NewDirectoryParser(cfg.ExcludeDirs, false, cfg.UseCodeIgnore)
+	dirParser.SetGitIgnore(cfg.UseGitIgnore)
+	dirParser.SetCodeIgnoreLegacy(cfg.LegacyNegate)
+	dirParser.SetHiddenPolicy(cfg.Hidden)
+	dirParser.SetIncludePatterns(cfg.Includes)
+	dirParser.SetOutputPath(cfg.OutputPath)
+	dirParser.SetJobs(cfg.Jobs)
+	dirParser.SetKeepGoing(cfg.KeepGoing)
+	dirParser.SetSymlinkMode(cfg.Symlinks)
 	fileParser := parser.NewFileParser()
+	fileParser.SetNormalizeNewlines(cfg.NormalizeEOL)
+	fileParser.SetSizeLimit(cfg.FileLimit)
 
-	// 현재 디렉토리 경로
-	currentDir, err := os.Getwd()
-	if err != nil {
+	// 루트 디렉토리별 파일 목록을 가져와서 타입과 포함 패턴으로 필터링
+	var typeFiles []string
+	var walkErrors []*parser.ParseError
+	var links []parser.Symlink
+	for _, rootDir := range cfg.RootDirs {
+		files, err := dirParser.Parse(rootDir)
+		if err != nil {
+			log.Fatal(err)
+		}
+		walkErrors = append(walkErrors, dirParser.Skipped()...)
+		links = append(links, dirParser.SelectLinks(rootDir, dirParser.Links(), cfg.FileTypes)...)
+		typeFiles = append(typeFiles, dirParser.SelectFiles(rootDir, files, cfg.FileTypes)...)
+	}
+
+	// 마크다운 생성기 생성
+	mdGen := generator.NewMarkdownGenerator(fileParser, cfg.OutputPath, cfg.MaxFileSizeMB)
+	if err := mdGen.SetRootDirs(cfg.RootDirs); err != nil {
 		log.Fatal(err)
 	}
+	mdGen.SetRepeatStructure(cfg.RepeatTree)
+	mdGen.SetLanguageOverrides(cfg.Languages)
+	mdGen.SetLinkStructure(cfg.LinkTree)
+	mdGen.SetBinaryMode(cfg.Binary)
+	mdGen.SetJobs(cfg.Jobs)
+	mdGen.SetKeepGoing(cfg.KeepGoing)
+	mdGen.SetWalkErrors(walkErrors)
+	mdGen.SetSymlinks(links)
+	if cfg.MaxTokens > 0 {
+		mdGen.SetMaxTokens(cfg.MaxTokens)
+	}
 
-	// 파일 목록 가져오기
-	allFiles, err := dirParser.Parse(currentDir)
+	// 템플릿 설정 (내장 템플릿 이름 또는 파일 경로)
+	tmpl, err := generator.LoadTemplate(cfg.Template)
 	if err != nil {
 		log.Fatal(err)
 	}
 
-	// 타입별 필터링
-	typeFiles := dirParser.GetFilesByTypes(allFiles, cfg.FileTypes)
+	if err := mdGen.SetTemplate(tmpl); err != nil {
+		log.Fatalf("%s: %v", cfg.Template, err)
+	}
+	mdGen.SetSplittable(generator.IsMarkdownTemplate(cfg.Template))
+	// 마크다운 생성
+	if err := mdGen.Generate(typeFiles); err != nil {
+		log.Fatal(err)
+	}
 
-	// 마크다운 생성기 생성
-	mdGen := generator.NewMarkdownGenerator(fileParser, cfg.OutputPath, cfg.MaxFileSizeMB)
+	// 토큰 기준으로 분할한 경우 파일별 추정 토큰 수 출력
+	if cfg.MaxTokens > 0 {
+		for _, part := range mdGen.Parts() {
+			fmt.Printf("%s: 약 %d 토큰\n", part.Path, part.Tokens)
+		}
+	}
 
-	// 기본 템플릿 설정
-	defaultTemplate := "# {{.ProjectName}}\n{{.Structure}}{{range .Files}}## {{.Path}}\n```{{if .Extension}}{{.Extension}}{{end}}\n{{.Content}}\n```\n{{end}}"
+	// 파일별 크기 제한으로 잘리거나 빠진 파일 출력
+	if truncations := mdGen.Truncations(); len(truncations) > 0 {
+		fmt.Printf("크기 제한으로 잘린 파일 %d개:\n", len(truncations))
+		for _, t := range truncations {
+			if t.Strategy == parser.TruncateSkip {
+				fmt.Printf("  %s (skip, %d 바이트)\n", t.Path, t.Size)
+			} else {
+				fmt.Printf("  %s (%s, %d줄 중 %d줄 생략)\n", t.Path, t.Strategy, t.Lines, t.OmittedLines)
+			}
+		}
+	}
 
-	if err := mdGen.SetTemplate(defaultTemplate); err != nil {
-		log.Fatal(err)
+	// 읽을 수 없어서 건너뛴 파일은 stderr로 출력하고 별도 종료 코드로 알림
+	if skipped := mdGen.Skipped(); len(skipped) > 0 {
+		fmt.Fprintf(os.Stderr, "읽을 수 없어서 건너뛴 파일 %d개:\n", len(skipped))
+		for _, s := range skipped {
+			fmt.Fprintf(os.Stderr, "  %s: %s\n", s.Path, s.Reason)
+		}
+		os.Exit(exitSkipped)
 	}
-	// 마크다운 생성
-	if err := mdGen.Generate(typeFiles); err != nil {
+}
+
+// 경로마다 포함 여부와 결정한 규칙 출력
+func checkIgnore(args []string) {
+	cfg, err := config.ParseCheckIgnoreFlags(os.Args[0], args)
+	if err != nil {
 		log.Fatal(err)
 	}
+
+	dirParser := parser.NewDirectoryParser(cfg.ExcludeDirs, false, cfg.UseCodeIgnore)
+	dirParser.SetGitIgnore(cfg.UseGitIgnore)
+	dirParser.SetCodeIgnoreLegacy(cfg.LegacyNegate)
+	dirParser.SetHiddenPolicy(cfg.Hidden)
+	dirParser.SetIncludePatterns(cfg.Includes)
+	dirParser.SetOutputPath(cfg.OutputPath)
+	dirParser.SetSymlinkMode(cfg.Symlinks)
+
+	// 경로마다 그 경로를 포함하는 루트 기준으로 확인 (메인 명령과 같은 루트 선택)
+	absRoots := make([]string, len(cfg.RootDirs))
+	for i, rootDir := range cfg.RootDirs {
+		if absRoots[i], err = filepath.Abs(rootDir); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	for _, path := range cfg.CheckPaths {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			log.Fatal(err)
+		}
+		owner := structure.OwnerRoot(absRoots, absPath)
+		if owner < 0 {
+			log.Fatalf("루트 디렉토리 밖의 경로: %s", path)
+		}
+		decision, err := dirParser.Explain(cfg.RootDirs[owner], path, cfg.FileTypes)
+		if err != nil {
+			log.Fatal(err)
+		}
+		fmt.Println(decision)
+	}
 }
@@ -4,53 +4,89 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
+	"runtime"
 	"strings"
+
+	"github.com/kihyun1998/codemd/internal/generator"
+	"github.com/kihyun1998/codemd/internal/parser"
 )
 
 type Config struct {
 	FileTypes     []string
+	Includes      []string // 출력할 파일을 고르는 glob 패턴들 (-type과 합집합)
 	OutputPath    string
 	ExcludeDirs   []string
 	UseCodeIgnore bool
+	LegacyNegate  bool // .codeignore의 부정 디렉토리 패턴이 디렉토리 안의 파일도 다시 포함 (이전 동작)
+	UseGitIgnore  bool
+	Hidden        parser.HiddenPolicy // 숨김 파일 처리 방식
 	ShowVersion   bool
 	MaxFileSizeMB int64
+	RepeatTree    bool
+	MaxTokens     int64
+	Languages     map[string]string // 확장자 또는 파일 이름별 코드 펜스 언어
+	Template      string            // 내장 템플릿 이름 또는 템플릿 파일 경로
+	LinkTree      bool
+	Binary        generator.BinaryMode // 바이너리 파일 처리 방식
+	NormalizeEOL  bool                 // CRLF 줄바꿈을 LF로 변환
+	FileLimit     parser.SizeLimit     // 파일별 최대 크기와 생략 방식
+	Jobs          int                  // 디렉토리 탐색과 파일 읽기를 동시에 하는 작업자 수
+	KeepGoing     bool                 // 읽을 수 없는 파일을 건너뛰고 계속 진행
+	Symlinks      parser.SymlinkMode   // 심볼릭 링크 처리 방식
+	RootDirs      []string             // 문서화할 루트 디렉토리들 (기본값: 현재 디렉토리)
+	CheckPaths    []string             // check-ignore로 확인할 경로들
 }
 
 // Usage 메시지 설정
 func SetUsage(programName string) {
 	flag.Usage = func() {
-		fmt.Fprintf(os.Stderr, "사용법: %s [옵션]\n\n옵션:\n", programName)
+		fmt.Fprintf(os.Stderr, "사용법: %s [옵션] [디렉토리...]\n\n옵션:\n", programName)
 		flag.PrintDefaults()
 		fmt.Fprintf(os.Stderr, "\n예시:\n")
 		fmt.Fprintf(os.Stderr, "  %s -version\n", programName)
 		fmt.Fprintf(os.Stderr, "  %s -type go,java\n", programName)
 		fmt.Fprintf(os.Stderr, "  %s -type go -exclude vendor,node_modules\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s -exclude 'internal/legacy,*.min.js,testdata/'\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s -type go,d.ts,Dockerfile -include 'cmd/**/*.go,*_test.go'\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s -gitignore -codeignore\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s -hidden-allow .github/,.golangci.yml\n", programName)
 		fmt.Fprintf(os.Stderr, "  %s -maxsize 20 -type go\n", programName) // 예시 추가
+		fmt.Fprintf(os.Stderr, "  %s -maxsize 5 -repeattree\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s -maxtokens 100000 -type go\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s -type h,tpl -lang h=cpp,tpl=html\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s -template xml\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s -template docs/custom.tmpl\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s -filelimit 200KB,3000lines -truncate headtail\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s -binary hex -type png,ico\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s -jobs 1 -type go\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s -keep-going -type go\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s -symlinks record-as-link\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s -type go ../service-a ../lib-b\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s check-ignore -c -type go internal/app.go\n", programName)
 	}
 }
 
 // 플래그 파싱
 func ParseFlags() (*Config, error) {
 	var (
-		types         string
-		output        string
-		exclude       string
-		useCodeIgnore bool
+		filters       filterFlags
 		showVersion   bool
 		maxFileSizeMB int64
+		repeatTree    bool
+		maxTokens     int64
+		languages     string
+		templateName  string
+		linkTree      bool
+		binary        string
+		normalizeEOL  bool
+		fileLimit     string
+		jobs          int
+		keepGoing     bool
+		truncate      string
 	)
 
-	flag.StringVar(&types, "type", "", "파일 확장자들 (쉼표로 구분)")
-	flag.StringVar(&types, "t", "", "파일 확장자들 (쉼표로 구분) (짧은 버전)")
-
-	flag.StringVar(&output, "out", "CODE.md", "출력 파일 경로")
-	flag.StringVar(&output, "o", "CODE.md", "출력 파일 경로 (짧은 버전)")
-
-	flag.StringVar(&exclude, "exclude", "", "제외할 디렉토리들 (쉼표로 구분)")
-	flag.StringVar(&exclude, "e", "", "제외할 디렉토리들 (쉼표로 구분) (짧은 버전)")
-
-	flag.BoolVar(&useCodeIgnore, "codeignore", false, ".codeignore 파일 사용 여부")
-	flag.BoolVar(&useCodeIgnore, "c", false, ".codeignore 파일 사용 여부 (짧은 버전)")
+	filters.register(flag.CommandLine)
 
 	flag.BoolVar(&showVersion, "version", false, "버전 정보 출력")
 	flag.BoolVar(&showVersion, "v", false, "버전 정보 출력 (짧은 버전)")
@@ -58,6 +94,32 @@ func ParseFlags() (*Config, error) {
 	flag.Int64Var(&maxFileSizeMB, "maxsize", 10, "출력 파일의 최대 크기 (MB 단위)")
 	flag.Int64Var(&maxFileSizeMB, "m", 10, "출력 파일의 최대 크기 (MB 단위) (짧은 버전)")
 
+	flag.Int64Var(&maxTokens, "maxtokens", 0, "출력 파일의 최대 토큰 수 (지정하면 -maxsize 대신 사용)")
+	flag.Int64Var(&maxTokens, "k", 0, "출력 파일의 최대 토큰 수 (지정하면 -maxsize 대신 사용) (짧은 버전)")
+
+	flag.StringVar(&languages, "lang", "", "코드 펜스 언어 지정 (예: h=cpp,Jenkinsfile=groovy)")
+	flag.StringVar(&languages, "l", "", "코드 펜스 언어 지정 (예: h=cpp,Jenkinsfile=groovy) (짧은 버전)")
+
+	flag.StringVar(&templateName, "template", "default", "내장 템플릿 이름(default, compact, xml, github) 또는 템플릿 파일 경로")
+	flag.StringVar(&templateName, "p", "default", "내장 템플릿 이름(default, compact, xml, github) 또는 템플릿 파일 경로 (짧은 버전)")
+
+	flag.BoolVar(&linkTree, "linktree", false, "프로젝트 구조의 파일을 목차 앵커로 연결 (코드 블록 대신 목록으로 출력)")
+
+	flag.StringVar(&binary, "binary", "skip", "바이너리 파일 처리 방식 (skip: 프로젝트 구조에만 표시, base64, hex)")
+
+	flag.StringVar(&fileLimit, "filelimit", "", "파일별 최대 크기 (예: 512KB, 2MB, 2000lines, 1MB,5000lines)")
+	flag.StringVar(&truncate, "truncate", "headtail", "파일별 최대 크기를 넘을 때 생략 방식 (skip, head, headtail, summary)")
+
+	flag.BoolVar(&normalizeEOL, "normalize-eol", false, "CRLF 줄바꿈을 LF로 변환")
+
+	flag.IntVar(&jobs, "jobs", runtime.NumCPU(), "디렉토리 탐색과 파일 읽기를 동시에 하는 작업자 수 (1이면 순차 처리)")
+	flag.IntVar(&jobs, "j", runtime.NumCPU(), "디렉토리 탐색과 파일 읽기를 동시에 하는 작업자 수 (1이면 순차 처리) (짧은 버전)")
+
+	flag.BoolVar(&keepGoing, "keep-going", false, "읽을 수 없는 파일과 디렉토리를 건너뛰고 계속 진행 (건너뛰면 종료 코드 3, 기본값은 즉시 실패)")
+
+	flag.BoolVar(&repeatTree, "repeattree", false, "분할된 모든 파일에 프로젝트 구조 반복 여부")
+	flag.BoolVar(&repeatTree, "r", false, "분할된 모든 파일에 프로젝트 구조 반복 여부 (짧은 버전)")
+
 	flag.Parse()
 
 	// -v 또는 -version 플래그만 있는 경우
@@ -69,12 +131,244 @@ func ParseFlags() (*Config, error) {
 		return nil, fmt.Errorf("최대 파일 크기는 0보다 커야 합니다")
 	}
 
-	return &Config{
-		FileTypes:     strings.Split(types, ","),
-		OutputPath:    output,
-		ExcludeDirs:   strings.Split(exclude, ","),
-		UseCodeIgnore: useCodeIgnore,
+	if maxTokens < 0 {
+		return nil, fmt.Errorf("최대 토큰 수는 0 이상이어야 합니다")
+	}
+
+	if jobs <= 0 {
+		return nil, fmt.Errorf("작업자 수는 0보다 커야 합니다")
+	}
+
+	languageMap, err := parseLanguages(languages)
+	if err != nil {
+		return nil, err
+	}
+
+	binaryMode, err := generator.ParseBinaryMode(binary)
+	if err != nil {
+		return nil, err
+	}
+
+	sizeLimit, err := parser.ParseSizeLimit(fileLimit, truncate)
+	if err != nil {
+		return nil, err
+	}
+
+	// 위치 인자로 받은 루트 디렉토리 확인
+	rootDirs := flag.Args()
+	if len(rootDirs) == 0 {
+		rootDirs = []string{"."}
+	}
+	if err := ValidateRootDirs(rootDirs); err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
 		ShowVersion:   showVersion,
 		MaxFileSizeMB: maxFileSizeMB,
-	}, nil
+		RepeatTree:    repeatTree,
+		MaxTokens:     maxTokens,
+		Languages:     languageMap,
+		Template:      templateName,
+		LinkTree:      linkTree,
+		Binary:        binaryMode,
+		NormalizeEOL:  normalizeEOL,
+		FileLimit:     sizeLimit,
+		Jobs:          jobs,
+		KeepGoing:     keepGoing,
+		RootDirs:      rootDirs,
+	}
+	if err := filters.apply(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// ValidateRootDirs는 루트 디렉토리들이 존재하는 디렉토리이고 서로 겹치지 않는지 확인
+// 같은 디렉토리나 다른 루트 안의 디렉토리를 지정하면 같은 파일이 두 번 출력되므로 거부
+func ValidateRootDirs(rootDirs []string) error {
+	resolved := make([]string, len(rootDirs))
+	for i, dir := range rootDirs {
+		info, err := os.Stat(dir)
+		if err != nil {
+			return fmt.Errorf("루트 디렉토리를 찾을 수 없습니다: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("디렉토리가 아닙니다: %s", dir)
+		}
+
+		// 심볼릭 링크로 지정한 같은 디렉토리도 찾도록 실제 경로로 비교
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("루트 디렉토리 경로 변환 실패: %w", err)
+		}
+		if resolved[i], err = filepath.EvalSymlinks(absDir); err != nil {
+			return fmt.Errorf("루트 디렉토리 경로 변환 실패: %w", err)
+		}
+
+		for j := 0; j < i; j++ {
+			if isWithin(resolved[j], resolved[i]) || isWithin(resolved[i], resolved[j]) {
+				return fmt.Errorf("루트 디렉토리가 겹칩니다: %s, %s (같은 디렉토리나 다른 루트 안의 디렉토리는 지정할 수 없음)", rootDirs[j], dir)
+			}
+		}
+	}
+	return nil
+}
+
+// isWithin은 path가 dir 자신이거나 dir 안에 있는지 확인
+func isWithin(dir string, path string) bool {
+	relPath, err := filepath.Rel(dir, path)
+	return err == nil && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator))
+}
+
+// parseLanguages는 "h=cpp,Jenkinsfile=groovy" 형식의 언어 지정을 해석
+func parseLanguages(value string) (map[string]string, error) {
+	languages := make(map[string]string)
+	for _, pair := range strings.Split(value, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		key, language, ok := strings.Cut(pair, "=")
+		key = strings.TrimSpace(key)
+		language = strings.TrimSpace(language)
+		if !ok || key == "" || language == "" {
+			return nil, fmt.Errorf("잘못된 언어 지정: %q (형식: 확장자=언어)", pair)
+		}
+		languages[key] = language
+	}
+	return languages, nil
+}
+
+// check-ignore 서브커맨드 플래그 파싱 (경로가 포함되거나 제외되는 이유 확인)
+func ParseCheckIgnoreFlags(programName string, args []string) (*Config, error) {
+	var (
+		filters filterFlags
+		roots   string
+	)
+
+	fs := flag.NewFlagSet("check-ignore", flag.ContinueOnError)
+	fs.Usage = func() {
+		fmt.Fprintf(os.Stderr, "사용법: %s check-ignore [옵션] 경로...\n\n옵션:\n", programName)
+		fs.PrintDefaults()
+		fmt.Fprintf(os.Stderr, "\n출력 형식: 상태(included/excluded) 단계(output/ignore/hidden/exclude/type/include) 규칙 경로\n")
+		fmt.Fprintf(os.Stderr, "\n예시:\n")
+		fmt.Fprintf(os.Stderr, "  %s check-ignore -c -g build/output.go\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s check-ignore -type go -exclude internal/legacy internal/legacy/a.go\n", programName)
+		fmt.Fprintf(os.Stderr, "  %s check-ignore -root ../service-a,../lib-b -symlinks skip ../lib-b/link.go\n", programName)
+	}
+
+	filters.register(fs)
+
+	fs.StringVar(&roots, "root", ".", "문서화 루트 디렉토리들 (쉼표로 구분, 메인 명령의 위치 인자와 같음)")
+
+	if err := fs.Parse(args); err != nil {
+		return nil, err
+	}
+
+	if fs.NArg() == 0 {
+		fs.Usage()
+		return nil, fmt.Errorf("확인할 경로를 지정해야 합니다")
+	}
+
+	rootDirs := strings.Split(roots, ",")
+	if err := ValidateRootDirs(rootDirs); err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{
+		RootDirs:   rootDirs,
+		CheckPaths: fs.Args(),
+	}
+	if err := filters.apply(cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// filterFlags는 메인 명령과 check-ignore가 함께 쓰는, 출력에 넣을 파일을 고르는 플래그 값
+type filterFlags struct {
+	types         string
+	includes      string
+	output        string
+	exclude       string
+	useCodeIgnore bool
+	legacyNegate  bool
+	useGitIgnore  bool
+	hidden        hiddenFlags
+	symlinks      string
+}
+
+// register는 파일 선택 플래그를 등록
+func (f *filterFlags) register(fs *flag.FlagSet) {
+	fs.StringVar(&f.types, "type", "", "파일 확장자나 이름들 (쉼표로 구분, 대소문자 구분 없음, 예: go,d.ts,Dockerfile)")
+	fs.StringVar(&f.types, "t", "", "파일 확장자나 이름들 (쉼표로 구분, 대소문자 구분 없음, 예: go,d.ts,Dockerfile) (짧은 버전)")
+
+	fs.StringVar(&f.includes, "include", "", "포함할 파일 glob 패턴들 (쉼표로 구분, 예: Makefile,*_test.go,cmd/**/*.go)")
+	fs.StringVar(&f.includes, "i", "", "포함할 파일 glob 패턴들 (쉼표로 구분, 예: Makefile,*_test.go,cmd/**/*.go) (짧은 버전)")
+
+	fs.StringVar(&f.output, "out", "CODE.md", "출력 파일 경로 (출력 파일과 분할 파트, 인덱스 파일은 항상 제외)")
+	fs.StringVar(&f.output, "o", "CODE.md", "출력 파일 경로 (출력 파일과 분할 파트, 인덱스 파일은 항상 제외) (짧은 버전)")
+
+	fs.StringVar(&f.exclude, "exclude", "", "제외할 이름, 루트 기준 경로, glob 패턴들 (쉼표로 구분, 예: vendor,internal/legacy,*.min.js)")
+	fs.StringVar(&f.exclude, "e", "", "제외할 이름, 루트 기준 경로, glob 패턴들 (쉼표로 구분, 예: vendor,internal/legacy,*.min.js) (짧은 버전)")
+
+	fs.BoolVar(&f.useCodeIgnore, "codeignore", false, ".codeignore 파일 사용 여부")
+	fs.BoolVar(&f.useCodeIgnore, "c", false, ".codeignore 파일 사용 여부 (짧은 버전)")
+	fs.BoolVar(&f.legacyNegate, "codeignore-legacy", false, ".codeignore의 부정 디렉토리 패턴(!dir/)이 다른 규칙으로 무시된 디렉토리 안의 파일도 다시 포함 (이전 동작)")
+
+	fs.BoolVar(&f.useGitIgnore, "gitignore", false, ".gitignore, .git/info/exclude, 전역 core.excludesFile 규칙 사용 여부")
+	fs.BoolVar(&f.useGitIgnore, "g", false, ".gitignore, .git/info/exclude, 전역 core.excludesFile 규칙 사용 여부 (짧은 버전)")
+
+	f.hidden.register(fs)
+
+	fs.StringVar(&f.symlinks, "symlinks", "follow-files", "심볼릭 링크 처리 방식 (skip, follow-files: 파일 링크만 읽고 디렉토리 링크는 \"이름 -> 대상\"으로 표시, follow: 디렉토리 링크도 탐색, record-as-link: 모든 링크를 \"이름 -> 대상\"으로만 표시)")
+}
+
+// apply는 파일 선택 플래그 값을 cfg에 설정
+func (f *filterFlags) apply(cfg *Config) error {
+	symlinkMode, err := parser.ParseSymlinkMode(f.symlinks)
+	if err != nil {
+		return err
+	}
+
+	cfg.FileTypes = strings.Split(f.types, ",")
+	cfg.Includes = strings.Split(f.includes, ",")
+	cfg.OutputPath = f.output
+	cfg.ExcludeDirs = strings.Split(f.exclude, ",")
+	cfg.UseCodeIgnore = f.useCodeIgnore
+	cfg.LegacyNegate = f.legacyNegate
+	cfg.UseGitIgnore = f.useGitIgnore
+	cfg.Hidden = f.hidden.policy()
+	cfg.Symlinks = symlinkMode
+	return nil
+}
+
+// hiddenFlags는 숨김 파일 관련 플래그 값
+type hiddenFlags struct {
+	includeDot     bool
+	hideUnderscore bool
+	allow          string
+}
+
+// register는 숨김 파일 관련 플래그를 등록
+func (h *hiddenFlags) register(fs *flag.FlagSet) {
+	fs.BoolVar(&h.includeDot, "include-hidden", false, "\".\"으로 시작하는 숨김 파일/디렉토리 포함 (.git은 제외)")
+	fs.BoolVar(&h.hideUnderscore, "hide-underscore", false, "\"_\"로 시작하는 파일/디렉토리도 숨김으로 처리")
+	fs.StringVar(&h.allow, "hidden-allow", "", "숨김이어도 포함할 이름이나 경로들 (쉼표로 구분, 예: .github/,.golangci.yml)")
+}
+
+// policy는 플래그 값을 숨김 파일 처리 방식으로 변환
+func (h *hiddenFlags) policy() parser.HiddenPolicy {
+	var allow []string
+	for _, entry := range strings.Split(h.allow, ",") {
+		if entry = strings.TrimSpace(entry); entry != "" {
+			allow = append(allow, entry)
+		}
+	}
+	return parser.HiddenPolicy{
+		IncludeDot:     h.includeDot,
+		HideUnderscore: h.hideUnderscore,
+		Allow:          allow,
+	}
 }
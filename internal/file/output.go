@@ -0,0 +1,52 @@
+package file
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// GeneratedMarker는 생성한 출력 파일 맨 앞에 붙는 표시 (다른 설정으로 만든 이전 출력도 알아보는 데 사용)
+const GeneratedMarker = "<!-- codemd:generated -->\n"
+
+// IsOutputName은 path가 basePath로 생성되는 출력 파일(단일 파일, 분할 파트, 인덱스 파일)인지 이름으로 확인
+// 두 경로는 같은 기준(예: 절대 경로)이어야 함
+func IsOutputName(basePath string, path string) bool {
+	if filepath.Dir(path) != filepath.Dir(basePath) {
+		return false
+	}
+
+	name, base := filepath.Base(path), filepath.Base(basePath)
+	if name == base {
+		return true
+	}
+
+	// generateFileName, generateIndexName과 같은 규칙 (CODE1.md, CODE.index.md)
+	ext := filepath.Ext(base)
+	stem := strings.TrimSuffix(base, ext)
+	if name == stem+".index"+ext {
+		return true
+	}
+	rest, ok := strings.CutPrefix(name, stem)
+	if !ok {
+		return false
+	}
+	digits, ok := strings.CutSuffix(rest, ext)
+	return ok && digits != "" && strings.Trim(digits, "0123456789") == ""
+}
+
+// HasGeneratedMarker는 파일이 GeneratedMarker로 시작하는지 확인
+func HasGeneratedMarker(path string) bool {
+	f, err := os.Open(path)
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+
+	head := make([]byte, len(GeneratedMarker))
+	if _, err := io.ReadFull(f, head); err != nil {
+		return false
+	}
+	return string(head) == GeneratedMarker
+}
@@ -0,0 +1,279 @@
+package file
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"path/filepath"
+)
+
+// PartWriter는 섹션을 하나씩 받아 크기 제한에 맞춰 분할 파일에 바로 기록하는 인터페이스
+type PartWriter interface {
+	WriteSection(section Section) error
+	Close() error
+	Abort()
+	Parts() []PartInfo
+}
+
+// PartInfo는 생성된 출력 파일 하나의 정보
+type PartInfo struct {
+	Path   string
+	Bytes  int64
+	Tokens int64 // 토큰 기준으로 분할할 때만 계산됨
+}
+
+// 머리말의 "Part N of M"에서 M은 마지막에야 알 수 있으므로 가장 긴 값으로 자리를 예약
+const reservedPartTotal = math.MaxInt32
+
+// partFile은 기록 중인 파트 하나 (본문은 임시 파일에 저장)
+type partFile struct {
+	tempPath   string
+	file       *os.File
+	writer     *bufio.Writer
+	titles     []string
+	bodyBytes  int64
+	bodySize   int64 // 분할 기준 단위(바이트 또는 토큰)의 본문 크기
+	headerSize int64
+}
+
+func (p *partFile) size() int64 {
+	return p.headerSize + p.bodySize
+}
+
+// partWriter는 PartWriter 구현체
+type partWriter struct {
+	splitter *fileSplitter
+	basePath string
+	parts    []*partFile // 기록이 끝난 파트들
+	current  *partFile
+	infos    []PartInfo // Close 이후 생성된 파일 정보
+}
+
+// NewPartWriter는 basePath를 기준으로 분할 파일을 기록하는 PartWriter를 생성
+func (fs *fileSplitter) NewPartWriter(basePath string) (PartWriter, error) {
+	pw := &partWriter{
+		splitter: fs,
+		basePath: basePath,
+	}
+	if err := pw.openPart(); err != nil {
+		return nil, err
+	}
+	return pw, nil
+}
+
+// WriteSection은 섹션을 통째로 현재 파트에 기록하고, 한 파트보다 큰 섹션만 줄 단위로 분할
+func (pw *partWriter) WriteSection(section Section) error {
+	maxSize := pw.splitter.maxFileSize
+	titleSize := pw.splitter.titleSize(section.Title)
+	size := pw.splitter.size(section.Content) + titleSize
+
+	if pw.current.size()+size <= maxSize {
+		return pw.add(section.Title, section.Content, titleSize)
+	}
+
+	// 현재 파트에 들어가지 않으면 새 파트에서 시작
+	if err := pw.nextPart(); err != nil {
+		return err
+	}
+	if pw.current.size()+size <= maxSize {
+		return pw.add(section.Title, section.Content, titleSize)
+	}
+
+	// 한 파트보다 큰 섹션은 분할하고, 마지막 조각 뒤에는 다음 섹션이 이어질 수 있음
+	chunks := pw.splitter.splitSection(section, maxSize-pw.current.headerSize-titleSize)
+	for i, chunk := range chunks {
+		if i > 0 {
+			if err := pw.nextPart(); err != nil {
+				return err
+			}
+		}
+		if err := pw.add(section.Title, chunk, titleSize); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Close는 남은 파트를 마무리하고 최종 파일들을 생성
+func (pw *partWriter) Close() error {
+	if err := pw.finishPart(); err != nil {
+		pw.Abort()
+		return err
+	}
+
+	// 최대 크기를 초과하지 않으면 단일 파일로 저장
+	marker := pw.splitter.marker
+	if len(pw.parts) <= 1 {
+		if len(pw.parts) == 0 {
+			return os.WriteFile(pw.basePath, []byte(marker), 0644)
+		}
+		if err := savePart(pw.basePath, marker, pw.parts[0].tempPath); err != nil {
+			pw.Abort()
+			return err
+		}
+		pw.infos = []PartInfo{pw.partInfo(pw.basePath, pw.parts[0], marker)}
+		return nil
+	}
+
+	// 각 부분을 머리말과 함께 개별 파일로 저장
+	titles := make([][]string, len(pw.parts))
+	for i, p := range pw.parts {
+		fileName := pw.splitter.generateFileName(pw.basePath, i+1)
+		header := marker + pw.splitter.renderPartHeader(i+1, len(pw.parts), p.titles)
+		if err := copyWithHeader(fileName, header, p.tempPath); err != nil {
+			pw.Abort()
+			return fmt.Errorf("파일 분할 저장 실패: %w", err)
+		}
+		titles[i] = p.titles
+		pw.infos = append(pw.infos, pw.partInfo(fileName, p, header))
+	}
+	pw.removeTemps()
+
+	if pw.splitter.partHeader != nil {
+		if err := pw.splitter.writeIndex(titles, pw.basePath); err != nil {
+			return fmt.Errorf("인덱스 파일 저장 실패: %w", err)
+		}
+	}
+
+	return nil
+}
+
+// Parts는 Close로 생성된 출력 파일들의 정보를 반환
+func (pw *partWriter) Parts() []PartInfo {
+	return pw.infos
+}
+
+// partInfo는 머리말을 포함한 최종 파일의 크기 정보를 계산
+func (pw *partWriter) partInfo(path string, p *partFile, header string) PartInfo {
+	info := PartInfo{
+		Path:  path,
+		Bytes: p.bodyBytes + int64(len(header)),
+	}
+	if pw.splitter.counter != nil {
+		info.Tokens = p.bodySize + pw.splitter.size(header)
+	}
+	return info
+}
+
+// Abort는 기록을 중단하고 임시 파일들을 모두 정리
+func (pw *partWriter) Abort() {
+	pw.removeTemps()
+}
+
+// removeTemps는 기록 중이거나 기록이 끝난 파트의 임시 파일을 삭제
+func (pw *partWriter) removeTemps() {
+	if pw.current != nil {
+		pw.current.file.Close()
+		os.Remove(pw.current.tempPath)
+		pw.current = nil
+	}
+	for _, p := range pw.parts {
+		os.Remove(p.tempPath)
+	}
+}
+
+// openPart는 새 파트의 임시 파일을 열고 머리말 크기를 미리 반영
+func (pw *partWriter) openPart() error {
+	tmp, err := os.CreateTemp(filepath.Dir(pw.basePath), ".codemd-part-*")
+	if err != nil {
+		return err
+	}
+
+	p := &partFile{
+		tempPath: tmp.Name(),
+		file:     tmp,
+		writer:   bufio.NewWriter(tmp),
+	}
+	p.headerSize = pw.splitter.size(pw.splitter.marker)
+	if pw.splitter.partHeader != nil {
+		p.headerSize += pw.splitter.size(pw.splitter.renderPartHeader(len(pw.parts)+1, reservedPartTotal, nil))
+	}
+	pw.current = p
+	return nil
+}
+
+// finishPart는 현재 파트를 닫고 기록이 끝난 파트 목록에 추가 (빈 파트는 버림)
+func (pw *partWriter) finishPart() error {
+	p := pw.current
+	pw.current = nil
+
+	if err := p.writer.Flush(); err != nil {
+		p.file.Close()
+		os.Remove(p.tempPath)
+		return err
+	}
+	if err := p.file.Close(); err != nil {
+		os.Remove(p.tempPath)
+		return err
+	}
+	if p.bodyBytes == 0 {
+		return os.Remove(p.tempPath)
+	}
+
+	pw.parts = append(pw.parts, p)
+	return nil
+}
+
+// nextPart는 현재 파트에 내용이 있으면 마무리하고 새 파트를 시작
+func (pw *partWriter) nextPart() error {
+	if pw.current.bodyBytes == 0 {
+		return nil
+	}
+	if err := pw.splitter.unsplittable; err != nil {
+		return err
+	}
+	if err := pw.finishPart(); err != nil {
+		return err
+	}
+	return pw.openPart()
+}
+
+// add는 현재 파트에 섹션 내용을 기록하고 머리말에 표시할 제목을 기록
+func (pw *partWriter) add(title, content string, titleSize int64) error {
+	p := pw.current
+	if title != "" {
+		p.titles = append(p.titles, title)
+		p.headerSize += titleSize
+	}
+	n, err := p.writer.WriteString(content)
+	p.bodyBytes += int64(n)
+	p.bodySize += pw.splitter.size(content)
+	return err
+}
+
+// savePart는 임시 파일을 최종 파일로 옮김 (머리말이 있으면 앞에 붙여서 복사)
+func savePart(dst, header, src string) error {
+	if header == "" {
+		return os.Rename(src, dst)
+	}
+	if err := copyWithHeader(dst, header, src); err != nil {
+		return err
+	}
+	return os.Remove(src)
+}
+
+// copyWithHeader는 머리말 뒤에 임시 파일의 내용을 이어 붙여 최종 파일을 생성
+func copyWithHeader(dst, header, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return err
+	}
+	defer in.Close()
+
+	out, err := os.Create(dst)
+	if err != nil {
+		return err
+	}
+
+	if _, err := io.WriteString(out, header); err != nil {
+		out.Close()
+		return err
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return err
+	}
+	return out.Close()
+}
@@ -5,64 +5,336 @@ import (
 	"os"
 	"path/filepath"
 	"strings"
+	"unicode/utf8"
+
+	"github.com/kihyun1998/codemd/internal/token"
 )
 
 // FileSplitter는 파일 분할 인터페이스
 type FileSplitter interface {
 	SplitIfNeeded(content string, basePath string) error
+	SplitSections(sections []Section, basePath string) error
+	NewPartWriter(basePath string) (PartWriter, error)
+	SetPartHeader(header *PartHeader)
+	SetMarker(marker string)
+	SetUnsplittable(err error)
+}
+
+// Section은 분할 시 하나의 단위로 다루는 콘텐츠 조각 (보통 파일 하나)
+type Section struct {
+	Title   string // 이어지는 파트의 헤더에 표시될 제목 (예: 파일 경로)
+	Content string
+}
+
+// PartHeader는 분할된 각 파트 앞에 붙일 머리말 설정
+type PartHeader struct {
+	ProjectName string
+	Structure   string // 비어있지 않으면 두 번째 파트부터 프로젝트 구조를 반복
 }
 
 // 파일 분할을 위한 구조체
 type fileSplitter struct {
-	maxFileSize int64 // 최대 파일 크기 (바이트)
+	maxFileSize  int64         // 최대 파일 크기 (바이트, counter가 있으면 토큰 수)
+	counter      token.Counter // nil이면 바이트 단위로 크기를 계산
+	partHeader   *PartHeader   // nil이면 머리말과 인덱스 파일을 만들지 않음
+	marker       string        // 생성한 파일 맨 앞에 붙일 표시 (빈 문자열이면 붙이지 않음)
+	unsplittable error         // nil이 아니면 두 번째 파트가 필요할 때 분할하지 않고 반환할 에러
 }
 
 // NewFileSplitter는 FileSplitter 인스턴스를 생성
 func NewFileSplitter(maxSizeMB int64) FileSplitter {
+	return NewFileSplitterWithBytes(maxSizeMB * 1024 * 1024) // MB를 바이트로 변환
+}
+
+// NewFileSplitterWithBytes는 바이트 단위 제한으로 FileSplitter 인스턴스를 생성
+func NewFileSplitterWithBytes(maxBytes int64) FileSplitter {
+	return &fileSplitter{
+		maxFileSize: maxBytes,
+	}
+}
+
+// NewTokenSplitter는 추정 토큰 수 기준으로 분할하는 FileSplitter 인스턴스를 생성
+func NewTokenSplitter(maxTokens int64, counter token.Counter) FileSplitter {
 	return &fileSplitter{
-		maxFileSize: maxSizeMB * 1024 * 1024, // MB를 바이트로 변환
+		maxFileSize: maxTokens,
+		counter:     counter,
 	}
 }
 
+// size는 분할 기준 단위(바이트 또는 토큰)로 텍스트의 크기를 계산
+func (fs *fileSplitter) size(text string) int64 {
+	if fs.counter == nil {
+		return int64(len(text))
+	}
+	return fs.counter.Count(text)
+}
+
+// SetPartHeader는 분할 시 각 파트에 붙일 머리말을 설정
+func (fs *fileSplitter) SetPartHeader(header *PartHeader) {
+	fs.partHeader = header
+}
+
+// SetMarker는 생성한 모든 파일(파트, 인덱스 포함) 맨 앞에 붙일 표시를 설정
+func (fs *fileSplitter) SetMarker(marker string) {
+	fs.marker = marker
+}
+
+// SetUnsplittable은 분할하면 형식이 깨지는 출력일 때 두 번째 파트 대신 반환할 에러를 설정 (nil이면 분할 허용)
+func (fs *fileSplitter) SetUnsplittable(err error) {
+	fs.unsplittable = err
+}
+
 // SplitIfNeeded는 콘텐츠를 여러 파일로 분할
 func (fs *fileSplitter) SplitIfNeeded(content string, basePath string) error {
-	contentSize := int64(len(content))
+	return fs.SplitSections([]Section{{Content: content}}, basePath)
+}
+
+// SplitSections는 섹션 경계를 지키면서 콘텐츠를 여러 파일로 분할
+func (fs *fileSplitter) SplitSections(sections []Section, basePath string) error {
+	writer, err := fs.NewPartWriter(basePath)
+	if err != nil {
+		return err
+	}
+
+	for _, section := range sections {
+		if err := writer.WriteSection(section); err != nil {
+			writer.Abort()
+			return err
+		}
+	}
+
+	return writer.Close()
+}
+
+// titleSize는 머리말의 파일 목록에서 제목 하나가 차지하는 크기를 반환
+func (fs *fileSplitter) titleSize(title string) int64 {
+	if title == "" || fs.partHeader == nil {
+		return 0
+	}
+	return fs.size(fs.renderTitle(title))
+}
+
+// renderPartHeader는 "Part 2 of 5" 형식의 파트 머리말을 생성
+func (fs *fileSplitter) renderPartHeader(index, total int, titles []string) string {
+	if fs.partHeader == nil {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("> ")
+	if fs.partHeader.ProjectName != "" {
+		sb.WriteString(fs.partHeader.ProjectName + " — ")
+	}
+	sb.WriteString(fmt.Sprintf("Part %d of %d\n>\n> Files in this part:\n", index, total))
+	for _, title := range titles {
+		sb.WriteString(fs.renderTitle(title))
+	}
+	sb.WriteString("\n")
+
+	if index > 1 && fs.partHeader.Structure != "" {
+		sb.WriteString(fs.partHeader.Structure)
+	}
+	return sb.String()
+}
+
+// renderTitle은 머리말의 파일 목록 한 줄을 생성
+func (fs *fileSplitter) renderTitle(title string) string {
+	return "> - " + title + "\n"
+}
+
+// writeIndex는 각 파일 경로가 어느 파트에 들어있는지 기록한 인덱스 파일을 생성
+func (fs *fileSplitter) writeIndex(partTitles [][]string, basePath string) error {
+	var (
+		order     []string
+		locations = make(map[string][]string)
+	)
+	for i, titles := range partTitles {
+		partName := filepath.Base(fs.generateFileName(basePath, i+1))
+		for _, title := range titles {
+			if _, exists := locations[title]; !exists {
+				order = append(order, title)
+			}
+			locations[title] = append(locations[title], partName)
+		}
+	}
+
+	var sb strings.Builder
+	sb.WriteString(fs.marker)
+	sb.WriteString("# ")
+	if fs.partHeader.ProjectName != "" {
+		sb.WriteString(fs.partHeader.ProjectName + " ")
+	}
+	sb.WriteString("Index\n\n")
+	sb.WriteString("| File | Part |\n")
+	sb.WriteString("|------|------|\n")
+	for _, title := range order {
+		sb.WriteString(fmt.Sprintf("| %s | %s |\n", title, strings.Join(locations[title], ", ")))
+	}
+
+	return os.WriteFile(fs.generateIndexName(basePath), []byte(sb.String()), 0644)
+}
+
+// splitSection은 하나의 섹션을 줄 경계에서 분할하고, 열린 코드 펜스를 닫았다가 다음 조각에서 다시 연다
+func (fs *fileSplitter) splitSection(section Section, limit int64) []string {
+	var (
+		chunks  []string
+		buf     strings.Builder
+		bufSize int64
+		fence   fenceState
+		partNo  = 1
+		base    int64 // 조각 시작 시 헤더와 펜스가 차지하는 크기
+	)
+
+	write := func(text string) {
+		buf.WriteString(text)
+		bufSize += fs.size(text)
+	}
+
+	// 지금 펜스를 닫는 데 필요한 크기
+	closingSize := func() int64 {
+		if !fence.open {
+			return 0
+		}
+		return fs.size("\n" + fence.marker + "\n")
+	}
+
+	rollover := func() {
+		if fence.open {
+			if !strings.HasSuffix(buf.String(), "\n") {
+				buf.WriteString("\n")
+			}
+			buf.WriteString(fence.marker + "\n")
+		}
+		chunks = append(chunks, buf.String())
+		buf.Reset()
+		bufSize = 0
 
-	// 최대 크기를 초과하지 않으면 단일 파일로 저장
-	if contentSize <= fs.maxFileSize {
-		return os.WriteFile(basePath, []byte(content), 0644)
+		partNo++
+		if section.Title != "" {
+			write(fmt.Sprintf("## %s (continued, part %d)\n\n", section.Title, partNo))
+		}
+		if fence.open {
+			write(fence.line)
+		}
+		base = bufSize
 	}
 
-	// 파일 분할이 필요한 경우
-	parts := fs.splitContent(content)
+	for _, line := range splitLines(section.Content) {
+		rest := line
+		for rest != "" {
+			room := limit - bufSize - closingSize()
+			if fs.size(rest) <= room {
+				write(rest)
+				break
+			}
+			if bufSize > base {
+				rollover()
+				continue
+			}
 
-	// 각 부분을 개별 파일로 저장
-	for i, part := range parts {
-		fileName := fs.generateFileName(basePath, i+1)
-		if err := os.WriteFile(fileName, []byte(part), 0644); err != nil {
-			return fmt.Errorf("파일 분할 저장 실패: %w", err)
+			// 한 줄이 빈 파트에도 들어가지 않으면 UTF-8 문자 경계에서 자름
+			cut := fs.cutPoint(rest, room)
+			write(rest[:cut])
+			rest = rest[cut:]
+			if rest != "" {
+				rollover()
+			}
 		}
+		fence.update(line)
 	}
+	chunks = append(chunks, buf.String())
 
-	return nil
+	return chunks
 }
 
-// splitContent는 콘텐츠를 여러 부분으로 분할
-func (fs *fileSplitter) splitContent(content string) []string {
-	var parts []string
-	contentSize := int64(len(content))
-	numParts := (contentSize + fs.maxFileSize - 1) / fs.maxFileSize
+// fenceState는 마크다운 코드 펜스의 열림 상태를 추적
+type fenceState struct {
+	open   bool
+	line   string // 펜스를 연 줄 (언어 정보 포함)
+	marker string // 펜스 문자열 (예: ```)
+}
 
-	for i := int64(0); i < numParts; i++ {
-		start := i * fs.maxFileSize
-		end := (i + 1) * fs.maxFileSize
-		if end > contentSize {
-			end = contentSize
+// update는 한 줄을 읽고 펜스 상태를 갱신
+func (f *fenceState) update(line string) {
+	trimmed := strings.TrimRight(strings.TrimLeft(line, " "), "\r\n")
+	marker := fenceMarker(trimmed)
+	if marker == "" {
+		return
+	}
+
+	if !f.open {
+		f.open = true
+		f.marker = marker
+		f.line = strings.TrimRight(line, "\r\n") + "\n"
+		return
+	}
+
+	// 같은 문자로 된, 여는 펜스 이상 길이의 펜스만 닫는 펜스로 인정
+	if marker[0] == f.marker[0] && len(marker) >= len(f.marker) && strings.TrimSpace(trimmed[len(marker):]) == "" {
+		*f = fenceState{}
+	}
+}
+
+// fenceMarker는 줄이 ``` 또는 ~~~ 펜스로 시작하면 펜스 문자열을 반환
+func fenceMarker(line string) string {
+	if line == "" || (line[0] != '`' && line[0] != '~') {
+		return ""
+	}
+	n := 0
+	for n < len(line) && line[n] == line[0] {
+		n++
+	}
+	if n < 3 {
+		return ""
+	}
+	return line[:n]
+}
+
+// splitLines는 줄바꿈 문자를 유지한 채로 콘텐츠를 줄 단위로 나눔
+func splitLines(content string) []string {
+	lines := strings.SplitAfter(content, "\n")
+	if len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	return lines
+}
+
+// cutPoint는 크기가 room을 넘지 않는 가장 긴 앞부분의 길이를 UTF-8 문자 경계로 반환 (최소 한 문자)
+func (fs *fileSplitter) cutPoint(s string, room int64) int {
+	if fs.counter == nil {
+		return runeBoundary(s, int(room))
+	}
+
+	// 토큰 수에 비례해 바이트 위치를 추정한 뒤, 제한을 넘으면 줄여나감
+	total := fs.size(s)
+	if total <= 0 {
+		return len(s)
+	}
+	cut := runeBoundary(s, int(int64(len(s))*room/total))
+	for cut > 1 && fs.size(s[:cut]) > room {
+		next := runeBoundary(s, cut*9/10)
+		if next >= cut {
+			break
 		}
-		parts = append(parts, content[start:end])
+		cut = next
 	}
+	return cut
+}
 
-	return parts
+// runeBoundary는 limit 바이트를 넘지 않는 가장 긴 UTF-8 문자 경계를 반환 (최소 한 문자)
+func runeBoundary(s string, limit int) int {
+	if limit >= len(s) {
+		return len(s)
+	}
+	cut := limit
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	if cut <= 0 {
+		_, size := utf8.DecodeRuneInString(s)
+		cut = size
+	}
+	return cut
 }
 
 // generateFileName은 분할된 파일의 이름을 생성
@@ -71,3 +343,10 @@ func (fs *fileSplitter) generateFileName(basePath string, index int) string {
 	baseWithoutExt := strings.TrimSuffix(basePath, ext)
 	return fmt.Sprintf("%s%d%s", baseWithoutExt, index, ext)
 }
+
+// generateIndexName은 인덱스 파일의 이름을 생성 (예: CODE.index.md)
+func (fs *fileSplitter) generateIndexName(basePath string) string {
+	ext := filepath.Ext(basePath)
+	baseWithoutExt := strings.TrimSuffix(basePath, ext)
+	return baseWithoutExt + ".index" + ext
+}
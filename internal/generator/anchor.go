@@ -0,0 +1,68 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// anchorSlugger는 GitHub와 같은 규칙으로 헤딩 앵커를 만들고 중복을 처리
+type anchorSlugger struct {
+	seen map[string]int
+}
+
+func newAnchorSlugger() *anchorSlugger {
+	return &anchorSlugger{seen: make(map[string]int)}
+}
+
+// Slug는 헤딩 텍스트의 앵커를 반환 (같은 앵커가 이미 있으면 -1, -2를 붙임)
+func (s *anchorSlugger) Slug(heading string) string {
+	base := githubAnchor(heading)
+	slug := base
+	for {
+		count, exists := s.seen[slug]
+		if !exists {
+			break
+		}
+		s.seen[slug] = count + 1
+		slug = fmt.Sprintf("%s-%d", base, count+1)
+	}
+	s.seen[slug] = 0
+	return slug
+}
+
+// githubAnchor는 소문자로 바꾸고 글자, 숫자, 밑줄, 하이픈 외의 문자를 지운 뒤 공백을 하이픈으로 바꿈
+// 한글 같은 비 ASCII 글자는 그대로 유지됨
+func githubAnchor(heading string) string {
+	var sb strings.Builder
+	for _, r := range strings.ToLower(strings.TrimSpace(heading)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsDigit(r), unicode.IsMark(r), r == '_', r == '-':
+			sb.WriteRune(r)
+		case r == ' ':
+			sb.WriteByte('-')
+		}
+	}
+	return sb.String()
+}
+
+// buildTOC는 파일 경로 목록으로 앵커 링크 목차를 생성
+func buildTOC(paths []string, anchors map[string]string) string {
+	if len(paths) == 0 {
+		return ""
+	}
+
+	var sb strings.Builder
+	sb.WriteString("## Table of Contents\n\n")
+	for _, path := range paths {
+		sb.WriteString(fmt.Sprintf("- [%s](#%s)\n", escapeLinkText(path), anchors[path]))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// escapeLinkText는 링크 텍스트에서 마크다운 문법으로 해석되는 문자를 이스케이프
+func escapeLinkText(text string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `[`, `\[`, `]`, `\]`, `*`, `\*`, `_`, `\_`, "`", "\\`")
+	return replacer.Replace(text)
+}
@@ -0,0 +1,52 @@
+package generator
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+)
+
+// BinaryMode는 바이너리 파일을 출력에 넣는 방식
+type BinaryMode string
+
+const (
+	BinarySkip   BinaryMode = "skip"   // 내용 없이 프로젝트 구조에만 표시 (기본값)
+	BinaryBase64 BinaryMode = "base64" // 76자마다 줄을 바꾼 base64
+	BinaryHex    BinaryMode = "hex"    // hexdump -C 형식의 16진수 덤프
+)
+
+// base64LineLen은 base64 내용의 한 줄 길이 (MIME과 같은 값)
+const base64LineLen = 76
+
+// ParseBinaryMode는 옵션 값을 바이너리 처리 방식으로 변환
+func ParseBinaryMode(value string) (BinaryMode, error) {
+	switch mode := BinaryMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return BinarySkip, nil
+	case BinarySkip, BinaryBase64, BinaryHex:
+		return mode, nil
+	}
+	return "", fmt.Errorf("알 수 없는 바이너리 처리 방식: %q (skip, base64, hex 중 하나)", value)
+}
+
+// encodeBinary는 바이너리 내용을 지정한 방식의 텍스트로 변환
+func encodeBinary(mode BinaryMode, data []byte) string {
+	if mode == BinaryHex {
+		return strings.TrimSuffix(hex.Dump(data), "\n")
+	}
+
+	encoded := base64.StdEncoding.EncodeToString(data)
+	var sb strings.Builder
+	for len(encoded) > base64LineLen {
+		sb.WriteString(encoded[:base64LineLen] + "\n")
+		encoded = encoded[base64LineLen:]
+	}
+	sb.WriteString(encoded)
+	return sb.String()
+}
+
+// binaryNote는 프로젝트 구조에서 바이너리 파일 이름 뒤에 붙는 설명
+func binaryNote(size int64) string {
+	return "binary, " + humanSize(size)
+}
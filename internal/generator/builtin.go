@@ -0,0 +1,104 @@
+package generator
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+)
+
+// DefaultTemplateName은 -template 옵션을 지정하지 않았을 때 사용하는 내장 템플릿
+const DefaultTemplateName = "default"
+
+// 내장 템플릿
+// header와 file 템플릿을 정의하면 출력 분할 시 파일 경계가 유지됨
+var builtinTemplates = map[string]string{
+	// 프로젝트 구조와 파일별 코드 블록
+	"default": `{{define "header"}}# {{.ProjectName}}
+{{.Structure}}{{.TOC}}{{end}}{{define "file"}}## {{.Path}}
+{{.Fence}}{{.Language}}
+{{.Content}}
+{{.Fence}}
+{{end}}`,
+
+	// 프로젝트 구조 없이 코드 블록만 나열
+	"compact": `{{define "header"}}# {{.ProjectName}}
+{{end}}{{define "file"}}### {{.Path}}
+{{.Fence}}{{.Language}}
+{{.Content}}
+{{.Fence}}
+{{end}}`,
+
+	// LLM 프롬프트에 넣기 좋은 XML 태그 형식
+	"xml": `{{define "header"}}<project name="{{html .ProjectName}}">
+<structure>
+{{html .Structure}}</structure>
+{{end}}{{define "file"}}<file path="{{html .Path}}" language="{{html .Language}}">
+{{html .Content}}
+</file>
+{{end}}{{define "skipped"}}<skipped>
+{{range .Skipped}}<file path="{{html .Path}}" reason="{{html .Reason}}"/>
+{{end}}</skipped>
+{{end}}{{define "footer"}}</project>
+{{end}}`,
+
+	// GitHub에서 파일별로 접고 펼 수 있는 형식
+	"github": `{{define "header"}}# {{.ProjectName}}
+{{.Structure}}{{.TOC}}{{end}}{{define "file"}}<a id="{{.Anchor}}"></a>
+<details>
+<summary><code>{{.Path}}</code></summary>
+
+{{.Fence}}{{.Language}}
+{{.Content}}
+{{.Fence}}
+
+</details>
+
+{{end}}`,
+}
+
+// 마크다운이 아닌 내장 템플릿 (분할 시 이어지는 제목과 코드 펜스 처리가 형식을 깨뜨리므로 분할하지 않음)
+var nonMarkdownTemplates = map[string]bool{"xml": true}
+
+// IsMarkdownTemplate은 출력을 분할할 수 있는 마크다운 형식의 템플릿인지 확인 (템플릿 파일은 마크다운으로 간주)
+func IsMarkdownTemplate(nameOrPath string) bool {
+	return !nonMarkdownTemplates[nameOrPath]
+}
+
+// 내장 템플릿에 공통으로 붙는 본문 (섹션 템플릿을 순서대로 실행)
+const builtinTemplateBody = `{{template "header" .}}{{range .Files}}{{template "file" .}}{{end}}{{template "footer" .}}`
+
+// BuiltinTemplateNames는 내장 템플릿 이름 목록을 반환
+func BuiltinTemplateNames() []string {
+	names := make([]string, 0, len(builtinTemplates))
+	for name := range builtinTemplates {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LoadTemplate은 내장 템플릿 이름 또는 템플릿 파일 경로로 템플릿 문자열을 반환
+func LoadTemplate(nameOrPath string) (string, error) {
+	if nameOrPath == "" {
+		nameOrPath = DefaultTemplateName
+	}
+
+	if tmpl, ok := builtinTemplates[nameOrPath]; ok {
+		body := builtinTemplateBody
+		if !strings.Contains(tmpl, `{{define "footer"}}`) {
+			body = strings.TrimSuffix(body, `{{template "footer" .}}`)
+		}
+		return tmpl + body, nil
+	}
+
+	content, err := os.ReadFile(nameOrPath)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return "", fmt.Errorf("템플릿을 찾을 수 없습니다: %s (내장 템플릿: %s)",
+				nameOrPath, strings.Join(BuiltinTemplateNames(), ", "))
+		}
+		return "", fmt.Errorf("템플릿 파일 읽기 실패: %w", err)
+	}
+	return string(content), nil
+}
@@ -0,0 +1,73 @@
+package generator
+
+import (
+	"fmt"
+	"strings"
+	"text/template"
+	"unicode"
+)
+
+// 템플릿에서 사용할 수 있는 함수들
+var templateFuncs = template.FuncMap{
+	"lineNumbers": lineNumbers,
+	"indent":      indent,
+	"trimSpace":   strings.TrimSpace,
+	"humanSize":   humanSize,
+	"upper":       strings.ToUpper,
+	"lower":       strings.ToLower,
+	"join":        join,
+	"slugify":     slugify,
+}
+
+// lineNumbers는 각 줄 앞에 줄 번호를 붙임
+func lineNumbers(content string) string {
+	lines := strings.Split(strings.TrimSuffix(content, "\n"), "\n")
+	width := len(fmt.Sprint(len(lines)))
+
+	var sb strings.Builder
+	for i, line := range lines {
+		sb.WriteString(fmt.Sprintf("%*d | %s\n", width, i+1, line))
+	}
+	return strings.TrimSuffix(sb.String(), "\n")
+}
+
+// indent는 각 줄 앞에 공백 n개를 붙임
+func indent(n int, content string) string {
+	pad := strings.Repeat(" ", n)
+	return pad + strings.ReplaceAll(content, "\n", "\n"+pad)
+}
+
+// humanSize는 바이트 크기를 "3.4 KB" 형식으로 변환
+func humanSize(size int64) string {
+	const unit = 1024
+	if size < unit {
+		return fmt.Sprintf("%d B", size)
+	}
+	div, exp := int64(unit), 0
+	for n := size / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(size)/float64(div), "KMGTPE"[exp])
+}
+
+// join은 파이프라인에서 쓰기 좋도록 구분자를 먼저 받는 strings.Join
+func join(sep string, elems []string) string {
+	return strings.Join(elems, sep)
+}
+
+// slugify는 문자열을 소문자와 하이픈으로 된 식별자로 변환
+func slugify(s string) string {
+	var sb strings.Builder
+	dash := false
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			sb.WriteRune(r)
+			dash = false
+		} else if !dash && sb.Len() > 0 {
+			sb.WriteByte('-')
+			dash = true
+		}
+	}
+	return strings.TrimSuffix(sb.String(), "-")
+}
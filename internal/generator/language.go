@@ -0,0 +1,227 @@
+package generator
+
+import (
+	"path/filepath"
+	"strings"
+)
+
+// 확장자별 코드 펜스 언어 식별자 (linguist 별칭 기준)
+var defaultExtensionLanguages = map[string]string{
+	"go":         "go",
+	"mod":        "go-module",
+	"java":       "java",
+	"kt":         "kotlin",
+	"kts":        "kotlin",
+	"scala":      "scala",
+	"groovy":     "groovy",
+	"gradle":     "groovy",
+	"c":          "c",
+	"h":          "c",
+	"cc":         "cpp",
+	"cpp":        "cpp",
+	"cxx":        "cpp",
+	"hh":         "cpp",
+	"hpp":        "cpp",
+	"hxx":        "cpp",
+	"cs":         "csharp",
+	"m":          "objective-c",
+	"mm":         "objective-cpp",
+	"swift":      "swift",
+	"rs":         "rust",
+	"dart":       "dart",
+	"js":         "javascript",
+	"mjs":        "javascript",
+	"cjs":        "javascript",
+	"jsx":        "jsx",
+	"ts":         "typescript",
+	"mts":        "typescript",
+	"cts":        "typescript",
+	"tsx":        "tsx",
+	"vue":        "vue",
+	"svelte":     "svelte",
+	"py":         "python",
+	"pyi":        "python",
+	"rb":         "ruby",
+	"php":        "php",
+	"pl":         "perl",
+	"pm":         "perl",
+	"lua":        "lua",
+	"r":          "r",
+	"jl":         "julia",
+	"ex":         "elixir",
+	"exs":        "elixir",
+	"erl":        "erlang",
+	"hs":         "haskell",
+	"clj":        "clojure",
+	"fs":         "fsharp",
+	"ml":         "ocaml",
+	"zig":        "zig",
+	"sh":         "bash",
+	"bash":       "bash",
+	"zsh":        "zsh",
+	"fish":       "fish",
+	"ps1":        "powershell",
+	"psm1":       "powershell",
+	"bat":        "batch",
+	"cmd":        "batch",
+	"sql":        "sql",
+	"html":       "html",
+	"htm":        "html",
+	"css":        "css",
+	"scss":       "scss",
+	"sass":       "sass",
+	"less":       "less",
+	"xml":        "xml",
+	"svg":        "xml",
+	"json":       "json",
+	"jsonc":      "jsonc",
+	"yml":        "yaml",
+	"yaml":       "yaml",
+	"toml":       "toml",
+	"ini":        "ini",
+	"cfg":        "ini",
+	"properties": "properties",
+	"md":         "markdown",
+	"markdown":   "markdown",
+	"rst":        "rst",
+	"tex":        "latex",
+	"proto":      "protobuf",
+	"graphql":    "graphql",
+	"gql":        "graphql",
+	"tf":         "hcl",
+	"hcl":        "hcl",
+	"cmake":      "cmake",
+	"mk":         "makefile",
+	"dockerfile": "dockerfile",
+	"diff":       "diff",
+	"patch":      "diff",
+}
+
+// 확장자 없이 이름으로 알 수 있는 파일들
+var defaultFilenameLanguages = map[string]string{
+	"Dockerfile":     "dockerfile",
+	"Containerfile":  "dockerfile",
+	"Makefile":       "makefile",
+	"GNUmakefile":    "makefile",
+	"makefile":       "makefile",
+	"CMakeLists.txt": "cmake",
+	"Jenkinsfile":    "groovy",
+	"Vagrantfile":    "ruby",
+	"Gemfile":        "ruby",
+	"Rakefile":       "ruby",
+	"go.mod":         "go-module",
+	"go.sum":         "text",
+	".bashrc":        "bash",
+	".zshrc":         "zsh",
+	".gitignore":     "gitignore",
+	".codeignore":    "gitignore",
+	".dockerignore":  "gitignore",
+	".editorconfig":  "editorconfig",
+}
+
+// shebang 인터프리터별 언어
+var defaultInterpreterLanguages = map[string]string{
+	"sh":      "bash",
+	"bash":    "bash",
+	"dash":    "bash",
+	"zsh":     "zsh",
+	"fish":    "fish",
+	"python":  "python",
+	"node":    "javascript",
+	"deno":    "typescript",
+	"ruby":    "ruby",
+	"perl":    "perl",
+	"php":     "php",
+	"lua":     "lua",
+	"Rscript": "r",
+	"pwsh":    "powershell",
+}
+
+// LanguageRegistry는 파일 경로와 내용으로 코드 펜스 언어를 결정
+type LanguageRegistry struct {
+	overrides    map[string]string // 사용자 지정 (파일 이름 또는 확장자)
+	extensions   map[string]string
+	filenames    map[string]string
+	interpreters map[string]string
+}
+
+// NewLanguageRegistry는 기본 언어 매핑을 가진 LanguageRegistry를 생성
+func NewLanguageRegistry() *LanguageRegistry {
+	return &LanguageRegistry{
+		overrides:    make(map[string]string),
+		extensions:   defaultExtensionLanguages,
+		filenames:    defaultFilenameLanguages,
+		interpreters: defaultInterpreterLanguages,
+	}
+}
+
+// SetOverride는 파일 이름 또는 확장자(점 없이)에 대한 언어를 사용자 지정
+func (r *LanguageRegistry) SetOverride(key, language string) {
+	r.overrides[strings.TrimPrefix(key, ".")] = language
+}
+
+// Detect는 사용자 지정, 파일 이름, 확장자, shebang 순서로 언어를 찾음
+// 찾지 못하면 확장자를 그대로 반환
+func (r *LanguageRegistry) Detect(path string, content string) string {
+	name := filepath.Base(path)
+	ext := strings.TrimPrefix(filepath.Ext(name), ".")
+
+	if lang, ok := r.overrides[name]; ok {
+		return lang
+	}
+	if lang, ok := r.overrides[ext]; ok && ext != "" {
+		return lang
+	}
+
+	if lang, ok := r.filenames[name]; ok {
+		return lang
+	}
+	// Dockerfile.dev 같은 변형
+	if strings.HasPrefix(name, "Dockerfile.") {
+		return "dockerfile"
+	}
+
+	if lang, ok := r.extensions[strings.ToLower(ext)]; ok {
+		return lang
+	}
+
+	if ext == "" {
+		if lang := r.detectShebang(content); lang != "" {
+			return lang
+		}
+	}
+
+	return ext
+}
+
+// detectShebang은 "#!/usr/bin/env python3" 같은 첫 줄에서 언어를 찾음
+func (r *LanguageRegistry) detectShebang(content string) string {
+	if !strings.HasPrefix(content, "#!") {
+		return ""
+	}
+
+	line := content[2:]
+	if i := strings.IndexByte(line, '\n'); i >= 0 {
+		line = line[:i]
+	}
+	fields := strings.Fields(line)
+	if len(fields) == 0 {
+		return ""
+	}
+
+	interpreter := filepath.Base(fields[0])
+	if interpreter == "env" {
+		// env -S 같은 옵션은 건너뜀
+		interpreter = ""
+		for _, field := range fields[1:] {
+			if !strings.HasPrefix(field, "-") {
+				interpreter = field
+				break
+			}
+		}
+	}
+
+	// python3, python3.11 같은 버전 접미사 제거
+	interpreter = strings.TrimRight(interpreter, "0123456789.")
+	return r.interpreters[interpreter]
+}
@@ -1,18 +1,36 @@
 package generator
 
 import (
+	"crypto/sha256"
 	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
+	"time"
 
 	"github.com/kihyun1998/codemd/internal/file"
 	"github.com/kihyun1998/codemd/internal/parser"
 	"github.com/kihyun1998/codemd/internal/structure"
+	"github.com/kihyun1998/codemd/internal/token"
 )
 
 type MarkdownGenerator interface {
 	Generate(files []string) error
 	SetTemplate(template string) error
+	SetRepeatStructure(repeat bool)
+	SetSplittable(split bool)
+	SetMaxTokens(maxTokens int64)
+	SetLanguageOverrides(overrides map[string]string)
+	SetLinkStructure(link bool)
+	SetRootDirs(rootDirs []string) error
+	SetBinaryMode(mode BinaryMode)
+	SetJobs(jobs int)
+	SetKeepGoing(keep bool)
+	SetWalkErrors(errs []*parser.ParseError)
+	Skipped() []SkippedFile
+	SetSymlinks(links []parser.Symlink)
+	Parts() []file.PartInfo
+	Truncations() []parser.Truncation
 }
 
 // 마크다운 생성기 구조체
@@ -20,43 +38,83 @@ type markdownGenerator struct {
 	fileParser  parser.FileParser
 	processor   *templateProcessor
 	outputPath  string
-	rootDir     string
+	rootDirs    []string // 절대 경로로 변환된 루트 디렉토리들
+	rootLabels  []string // 여러 루트일 때 경로 앞에 붙는 루트 이름
 	projectName string
 	splitter    file.FileSplitter
+	languages   *LanguageRegistry
+
+	repeatStructure bool                 // 분할된 모든 파트에 프로젝트 구조 반복 여부
+	unsplittable    bool                 // 분할하면 형식이 깨지는 템플릿이라 크기 제한을 넘으면 실패
+	linkStructure   bool                 // 프로젝트 구조의 파일을 목차 앵커로 연결할지 여부
+	binaryMode      BinaryMode           // 바이너리 파일을 출력에 넣는 방식
+	jobs            int                  // 파일을 동시에 읽는 작업자 수
+	keepGoing       bool                 // 읽을 수 없는 파일을 건너뛰고 계속할지 여부
+	walkErrors      []*parser.ParseError // 디렉토리 탐색에서 건너뛴 경로
+	skipped         []SkippedFile        // 마지막 Generate에서 건너뛴 파일 (탐색 단계 포함)
+	symlinks        []parser.Symlink     // 내용 없이 프로젝트 구조에 "이름 -> 대상"으로 표시할 링크
+	binaries        map[string]bool      // 마지막 Generate에서 바이너리로 판단된 파일 경로
+	truncations     []parser.Truncation  // 마지막 Generate에서 크기 제한으로 잘리거나 빠진 파일
+	anchors         map[string]string    // 상대 경로별 헤딩 앵커
+	parts           []file.PartInfo      // 마지막 Generate로 생성된 출력 파일 정보
 }
 
-// 생성자
+// 생성자 (루트 디렉토리는 SetRootDirs로 지정하며, 기본값은 현재 디렉토리)
 func NewMarkdownGenerator(fp parser.FileParser, outputPath string, maxFileSizeMB int64) MarkdownGenerator {
-	rootDir, err := os.Getwd()
-	if err != nil {
-		rootDir = ""
+	mg := &markdownGenerator{
+		fileParser: fp,
+		outputPath: outputPath,
+		splitter:   file.NewFileSplitter(maxFileSizeMB),
+		languages:  NewLanguageRegistry(),
+		binaryMode: BinarySkip,
+		jobs:       1,
 	}
+	mg.SetRootDirs([]string{"."})
+	return mg
+}
+
+// 문서화할 루트 디렉토리 설정 (여러 개면 루트마다 구조를 나누어 출력)
+func (mg *markdownGenerator) SetRootDirs(rootDirs []string) error {
+	absDirs := make([]string, len(rootDirs))
+	for i, dir := range rootDirs {
+		absDir, err := filepath.Abs(dir)
+		if err != nil {
+			return fmt.Errorf("루트 디렉토리 경로 변환 실패: %w", err)
+		}
+		absDirs[i] = absDir
+	}
+
+	mg.rootDirs = absDirs
+	mg.rootLabels = structure.RootLabels(absDirs)
 
 	// 프로젝트 이름 추출
-	projectName := filepath.Base(rootDir)
+	mg.projectName = strings.Join(mg.rootLabels, ", ")
+	return nil
+}
 
-	return &markdownGenerator{
-		fileParser:  fp,
-		outputPath:  outputPath,
-		rootDir:     rootDir,
-		projectName: projectName,
-		splitter:    file.NewFileSplitter(maxFileSizeMB),
+// 상대 경로 변환 함수 (여러 루트일 때는 루트 이름을 앞에 붙임)
+func (mg *markdownGenerator) toRelativePath(path string) string {
+	absolutePath, err := filepath.Abs(path)
+	if err != nil {
+		return path
 	}
-}
 
-// 상대 경로 변환 함수
-func (mg *markdownGenerator) toRelativePath(absolutePath string) string {
-	if mg.rootDir == "" {
-		return absolutePath
+	owner := structure.OwnerRoot(mg.rootDirs, absolutePath)
+	if owner < 0 {
+		return path
 	}
 
-	relativePath, err := filepath.Rel(mg.rootDir, absolutePath)
+	relativePath, err := filepath.Rel(mg.rootDirs[owner], absolutePath)
 	if err != nil {
-		return absolutePath
+		return path
 	}
 
 	// 윈도우 스타일 경로를 UNIX 스타일 경로로 변환
-	return filepath.ToSlash(relativePath)
+	relativePath = filepath.ToSlash(relativePath)
+	if len(mg.rootDirs) > 1 {
+		relativePath = mg.rootLabels[owner] + "/" + relativePath
+	}
+	return relativePath
 }
 
 // 템플릿 설정
@@ -69,47 +127,364 @@ func (mg *markdownGenerator) SetTemplate(template string) error {
 	return nil
 }
 
+// 분할된 모든 파트에 프로젝트 구조를 반복할지 설정
+func (mg *markdownGenerator) SetRepeatStructure(repeat bool) {
+	mg.repeatStructure = repeat
+}
+
+// 출력을 여러 파일로 분할할 수 있는 템플릿인지 설정 (false면 크기 제한을 넘을 때 분할하지 않고 실패)
+func (mg *markdownGenerator) SetSplittable(split bool) {
+	mg.unsplittable = !split
+}
+
+// 바이트 대신 추정 토큰 수 기준으로 출력을 분할하도록 설정
+func (mg *markdownGenerator) SetMaxTokens(maxTokens int64) {
+	mg.splitter = file.NewTokenSplitter(maxTokens, token.NewEstimator())
+}
+
+// 확장자 또는 파일 이름별 코드 펜스 언어를 사용자 지정
+func (mg *markdownGenerator) SetLanguageOverrides(overrides map[string]string) {
+	for key, language := range overrides {
+		mg.languages.SetOverride(key, language)
+	}
+}
+
+// 프로젝트 구조를 코드 블록 대신 파일 앵커로 연결된 목록으로 출력할지 설정
+func (mg *markdownGenerator) SetLinkStructure(link bool) {
+	mg.linkStructure = link
+}
+
+// 마지막으로 생성할 때 크기 제한으로 잘리거나 빠진 파일들 (경로는 상대 경로)
+func (mg *markdownGenerator) Truncations() []parser.Truncation {
+	return mg.truncations
+}
+
+// 바이너리 파일 처리 방식 설정 (기본값은 프로젝트 구조에만 표시)
+func (mg *markdownGenerator) SetBinaryMode(mode BinaryMode) {
+	mg.binaryMode = mode
+}
+
+// 동시에 파일을 읽는 작업자 수 설정 (출력 순서는 작업자 수와 관계없이 같음)
+func (mg *markdownGenerator) SetJobs(jobs int) {
+	mg.jobs = jobs
+}
+
+// 읽을 수 없는 파일을 에러 대신 건너뛰고 "Skipped files" 목록에 넣을지 설정
+func (mg *markdownGenerator) SetKeepGoing(keep bool) {
+	mg.keepGoing = keep
+}
+
+// 디렉토리 탐색에서 건너뛴 경로 설정 (건너뛴 파일 목록의 앞부분에 표시)
+func (mg *markdownGenerator) SetWalkErrors(errs []*parser.ParseError) {
+	mg.walkErrors = errs
+}
+
+// 마지막으로 생성할 때 건너뛴 파일들 (경로는 상대 경로)
+func (mg *markdownGenerator) Skipped() []SkippedFile {
+	return mg.skipped
+}
+
+// 프로젝트 구조에 "이름 -> 대상"으로 표시할 심볼릭 링크 설정
+func (mg *markdownGenerator) SetSymlinks(links []parser.Symlink) {
+	mg.symlinks = links
+}
+
+// 마지막으로 생성된 출력 파일들의 정보
+func (mg *markdownGenerator) Parts() []file.PartInfo {
+	return mg.parts
+}
+
 // 마크다운 생성
 func (mg *markdownGenerator) Generate(files []string) error {
-	var fileDataList []FileData
-
 	// 프로젝트 구조 생성
-	tree := structure.NewDirectoryTree(mg.rootDir)
-	if err := tree.BuildTree(files); err != nil {
+	var tree structure.Tree
+	if len(mg.rootDirs) > 1 {
+		tree = structure.NewMultiRootTree(mg.rootDirs)
+	} else {
+		tree = structure.NewDirectoryTree(mg.rootDirs[0])
+	}
+	absFiles := make([]string, len(files))
+	for i, path := range files {
+		absPath, err := filepath.Abs(path)
+		if err != nil {
+			return fmt.Errorf("파일 경로 변환 실패: %w", err)
+		}
+		absFiles[i] = absPath
+	}
+	if err := tree.BuildTree(absFiles); err != nil {
 		return fmt.Errorf("프로젝트 구조 생성 실패: %w", err)
 	}
-
-	for _, file := range files {
-		content, err := mg.fileParser.ReadContent(file)
+	for _, link := range mg.symlinks {
+		absPath, err := filepath.Abs(link.Path)
 		if err != nil {
-			return err
+			return fmt.Errorf("파일 경로 변환 실패: %w", err)
 		}
-
-		ext := filepath.Ext(file)
-		if ext != "" {
-			ext = ext[1:]
+		if err := tree.AddLink(absPath, link.Target); err != nil {
+			return fmt.Errorf("프로젝트 구조 생성 실패: %w", err)
 		}
+	}
 
-		// 상대 경로로 변환
-		relativePath := mg.toRelativePath(file)
+	mg.skipped = nil
+	for _, walkErr := range mg.walkErrors {
+		mg.skipped = append(mg.skipped, mg.newSkippedFile(walkErr))
+	}
 
-		fileDataList = append(fileDataList, FileData{
-			Path:      relativePath,
-			Content:   content,
-			Extension: ext,
-		})
+	// 바이너리 파일과 크기 제한으로 건너뛸 파일, 읽을 수 없는 파일은 프로젝트 구조에 표시하고, 내용을 넣지 않으면 목록에서 제외
+	files, err := mg.classifyFiles(tree, files, absFiles)
+	if err != nil {
+		return err
 	}
 
+	// 목차와 파일 헤딩에 사용할 앵커 생성
+	paths := make([]string, len(files))
+	for i, path := range files {
+		paths[i] = mg.toRelativePath(path)
+	}
+	mg.anchors = mg.buildAnchors(paths)
+
 	data := TemplateData{
 		ProjectName: mg.projectName,
 		Structure:   tree.ToMarkdown(),
-		Files:       fileDataList,
+		TOC:         buildTOC(paths, mg.anchors),
+		Skipped:     mg.skipped,
+	}
+	if mg.linkStructure {
+		data.Structure = tree.ToLinkedMarkdown(mg.anchors)
 	}
 
-	result, err := mg.processor.Execute(data)
+	// 분할 시 각 파트에 붙일 머리말
+	partHeader := &file.PartHeader{ProjectName: mg.projectName}
+	if mg.repeatStructure {
+		partHeader.Structure = data.Structure
+	}
+	mg.splitter.SetPartHeader(partHeader)
+	mg.splitter.SetMarker(file.GeneratedMarker) // 다음 실행에서 이전 출력을 알아보기 위한 표시
+	mg.splitter.SetUnsplittable(nil)
+	if mg.unsplittable {
+		mg.splitter.SetUnsplittable(fmt.Errorf("출력이 크기 제한을 넘지만 이 템플릿은 여러 파일로 분할할 수 없습니다 (-maxsize나 -maxtokens를 늘리거나 마크다운 템플릿 사용)"))
+	}
+
+	writer, err := mg.splitter.NewPartWriter(mg.outputPath)
 	if err != nil {
 		return err
 	}
 
-	return mg.splitter.SplitIfNeeded(result, mg.outputPath)
+	if err := mg.writeSections(writer, files, data); err != nil {
+		writer.Abort()
+		return err
+	}
+
+	if err := writer.Close(); err != nil {
+		return err
+	}
+	mg.parts = writer.Parts()
+	return nil
+}
+
+// classifyFiles는 바이너리 파일과 크기 제한으로 건너뛸 파일에 설명을 붙이고 내용을 출력할 파일 목록을 반환
+// keepGoing이면 읽을 수 없는 파일도 설명을 붙이고 건너뜀
+// 파일 검사는 동시에 하고, 설명과 목록은 files 순서대로 기록
+func (mg *markdownGenerator) classifyFiles(tree structure.Tree, files []string, absFiles []string) ([]string, error) {
+	type fileKind struct {
+		binary  bool
+		size    int64
+		skipped *parser.Truncation
+		err     error
+	}
+	kinds := make([]fileKind, len(files))
+	parallelFor(len(files), mg.jobs, func(i int) {
+		kind := &kinds[i]
+		if kind.binary, kind.err = mg.fileParser.IsBinary(files[i]); kind.err != nil {
+			return
+		}
+		if !kind.binary {
+			kind.skipped, kind.err = mg.fileParser.CheckSkip(files[i])
+			return
+		}
+		if info, err := os.Stat(files[i]); err == nil {
+			kind.size = info.Size()
+		}
+	})
+
+	mg.binaries = make(map[string]bool)
+	mg.truncations = nil
+	kept := make([]string, 0, len(files))
+	for i, path := range files {
+		kind := kinds[i]
+		if kind.err != nil {
+			parseErr, ok := asParseError(kind.err)
+			if !mg.keepGoing || !ok {
+				return nil, kind.err
+			}
+			skipped := mg.newSkippedFile(parseErr)
+			tree.Annotate(absFiles[i], "skipped, "+skipped.Reason)
+			mg.skipped = append(mg.skipped, skipped)
+			continue
+		}
+		if !kind.binary {
+			if kind.skipped != nil {
+				tree.Annotate(absFiles[i], "skipped, "+humanSize(kind.skipped.Size))
+				kind.skipped.Path = mg.toRelativePath(path)
+				mg.truncations = append(mg.truncations, *kind.skipped)
+				continue
+			}
+			kept = append(kept, path)
+			continue
+		}
+
+		tree.Annotate(absFiles[i], binaryNote(kind.size))
+		mg.binaries[path] = true
+		if mg.binaryMode != BinarySkip {
+			kept = append(kept, path)
+		}
+	}
+	return kept, nil
+}
+
+// buildAnchors는 파일 경로별로 "## 경로" 헤딩의 GitHub 앵커를 계산
+// 머리말의 프로젝트 이름, 프로젝트 구조, 목차 헤딩과 겹치지 않도록 먼저 등록
+func (mg *markdownGenerator) buildAnchors(paths []string) map[string]string {
+	slugger := newAnchorSlugger()
+	slugger.Slug(mg.projectName)
+	slugger.Slug("Project Structure")
+	slugger.Slug("Table of Contents")
+
+	anchors := make(map[string]string, len(paths))
+	for _, path := range paths {
+		anchors[path] = slugger.Slug(path)
+	}
+	return anchors
+}
+
+// writeSections는 파일을 동시에 읽고, 원래 순서대로 렌더링해서 바로 출력 파트에 기록
+// "file" 템플릿이 없으면 전체 문서를 한 번에 렌더링해서 하나의 섹션으로 취급
+func (mg *markdownGenerator) writeSections(writer file.PartWriter, files []string, data TemplateData) error {
+	if !mg.processor.HasSections() {
+		if err := mg.readFiles(files, func(fd FileData) error {
+			data.Files = append(data.Files, fd)
+			return nil
+		}); err != nil {
+			return err
+		}
+
+		data.Skipped = mg.skipped
+		result, err := mg.processor.Execute(data)
+		if err != nil {
+			return err
+		}
+		skipped, err := mg.renderSkipped(data)
+		if err != nil {
+			return err
+		}
+		return writer.WriteSection(file.Section{Content: result + skipped})
+	}
+
+	header, err := mg.processor.ExecuteTemplate(HeaderTemplateName, data)
+	if err != nil {
+		return err
+	}
+	if err := writer.WriteSection(file.Section{Content: header}); err != nil {
+		return err
+	}
+
+	if err := mg.readFiles(files, func(fd FileData) error {
+		content, err := mg.processor.ExecuteTemplate(FileTemplateName, fd)
+		if err != nil {
+			return err
+		}
+
+		return writer.WriteSection(file.Section{
+			Title:   fd.Path,
+			Content: content,
+		})
+	}); err != nil {
+		return err
+	}
+
+	// 파일을 읽는 중에 건너뛴 파일까지 포함해서 footer 앞에 목록 출력
+	data.Skipped = mg.skipped
+	skipped, err := mg.renderSkipped(data)
+	if err != nil {
+		return err
+	}
+	if skipped != "" {
+		if err := writer.WriteSection(file.Section{Content: skipped}); err != nil {
+			return err
+		}
+	}
+
+	footer, err := mg.processor.ExecuteTemplate(FooterTemplateName, data)
+	if err != nil {
+		return err
+	}
+	return writer.WriteSection(file.Section{Content: footer})
+}
+
+// readFileData는 파일 내용과 메타데이터를 읽어 템플릿 데이터로 변환 (크기 제한으로 잘렸으면 잘린 정보도 반환)
+// 여러 고루틴에서 동시에 호출되므로 생성기 상태를 바꾸지 않음
+func (mg *markdownGenerator) readFileData(path string) (FileData, *parser.Truncation, error) {
+	// 바이너리 파일은 원본 바이트를, 텍스트 파일은 UTF-8로 변환한 내용을 읽음
+	var text parser.TextContent
+	if mg.binaries[path] {
+		raw, err := mg.fileParser.ReadBytes(path)
+		if err != nil {
+			return FileData{}, nil, err
+		}
+		text.Content, text.Raw = string(raw), raw
+	} else {
+		var err error
+		if text, err = mg.fileParser.ReadText(path); err != nil {
+			return FileData{}, nil, err
+		}
+	}
+	content := text.Content
+	truncation := text.Truncation
+	if truncation != nil {
+		truncation.Path = mg.toRelativePath(path)
+	}
+
+	var modTime time.Time
+	if info, err := os.Stat(path); err == nil {
+		modTime = info.ModTime()
+	}
+
+	ext := filepath.Ext(path)
+	if ext != "" {
+		ext = ext[1:]
+	}
+
+	relativePath := mg.toRelativePath(path) // 상대 경로로 변환
+
+	// 크기와 해시는 디스크에 있는 원본 바이트 기준 (바이너리 파일은 내용만 텍스트로 변환)
+	var binary, language string
+	if mg.binaries[path] {
+		content = encodeBinary(mg.binaryMode, text.Raw)
+		binary = string(mg.binaryMode)
+		language = "text"
+	} else {
+		language = mg.languages.Detect(path, content)
+	}
+
+	// 줄 수는 잘리기 전 기준
+	lines := parser.CountLines(content)
+	if truncation != nil {
+		lines = truncation.Lines
+	}
+
+	return FileData{
+		Path:      relativePath,
+		Dir:       filepath.ToSlash(filepath.Dir(relativePath)),
+		Content:   content,
+		Extension: ext,
+		Language:  language,
+		Fence:     codeFence(content),
+		Anchor:    mg.anchors[relativePath],
+		Size:      int64(len(text.Raw)),
+		Lines:     lines,
+		ModTime:   modTime,
+		SHA256:    fmt.Sprintf("%x", sha256.Sum256(text.Raw)),
+		Binary:    binary,
+		Encoding:  text.Encoding,
+		Truncated: truncation != nil,
+	}, truncation, nil
 }
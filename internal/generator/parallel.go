@@ -0,0 +1,79 @@
+package generator
+
+import (
+	"sync"
+	"sync/atomic"
+
+	"github.com/kihyun1998/codemd/internal/parser"
+)
+
+// fileResult는 동시에 읽은 파일 하나의 결과
+type fileResult struct {
+	data       FileData
+	truncation *parser.Truncation
+	err        error
+}
+
+// parallelFor는 0부터 n-1까지의 fn을 jobs개의 작업자로 나눠 실행
+// 작업자는 채널 대신 공유 카운터에서 다음 번호를 가져가므로 파일마다 동기화 비용이 들지 않음
+func parallelFor(n int, jobs int, fn func(i int)) {
+	var next atomic.Int64
+	var wg sync.WaitGroup
+	for w := 0; w < max(min(jobs, n), 1); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := int(next.Add(1) - 1); i < n; i = int(next.Add(1) - 1) {
+				fn(i)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// readFiles는 파일을 최대 jobs개까지 동시에 읽고 files 순서대로 fn에 넘김 (keepGoing이면 읽을 수 없는 파일은 건너뜀)
+// 읽었지만 아직 넘기지 않은 파일은 jobs개를 넘지 않으므로 메모리 사용량이 제한됨
+func (mg *markdownGenerator) readFiles(files []string, fn func(FileData) error) error {
+	results := make([]chan fileResult, len(files))
+	for i := range results {
+		results[i] = make(chan fileResult, 1)
+	}
+
+	slots := make(chan struct{}, max(mg.jobs, 1))
+	done := make(chan struct{})
+	defer close(done)
+
+	go func() {
+		for i, path := range files {
+			select {
+			case slots <- struct{}{}:
+			case <-done:
+				return // fn이 오류를 반환해서 더 읽을 필요가 없음
+			}
+			go func() {
+				data, truncation, err := mg.readFileData(path)
+				results[i] <- fileResult{data: data, truncation: truncation, err: err}
+			}()
+		}
+	}()
+
+	for i := range files {
+		result := <-results[i]
+		<-slots
+		if result.err != nil {
+			parseErr, ok := asParseError(result.err)
+			if !mg.keepGoing || !ok {
+				return result.err
+			}
+			mg.skipped = append(mg.skipped, mg.newSkippedFile(parseErr))
+			continue
+		}
+		if result.truncation != nil {
+			mg.truncations = append(mg.truncations, *result.truncation)
+		}
+		if err := fn(result.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,56 @@
+package generator
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"strings"
+
+	"github.com/kihyun1998/codemd/internal/parser"
+)
+
+// SkippedFile은 -keep-going으로 에러 대신 건너뛴 파일이나 디렉토리
+type SkippedFile struct {
+	Path   string // 상대 경로
+	Reason string // 읽지 못한 이유 (예: permission denied)
+}
+
+// asParseError는 에러가 건너뛸 수 있는 파일 읽기 에러인지 확인
+func asParseError(err error) (*parser.ParseError, bool) {
+	var parseErr *parser.ParseError
+	ok := errors.As(err, &parseErr)
+	return parseErr, ok
+}
+
+// newSkippedFile은 파싱 에러를 건너뛴 파일 정보로 변환
+// 경로는 이미 Path에 있으므로 os.PathError의 원인만 이유로 사용
+func (mg *markdownGenerator) newSkippedFile(parseErr *parser.ParseError) SkippedFile {
+	reason := parseErr.Err
+	var pathErr *fs.PathError
+	if errors.As(reason, &pathErr) {
+		reason = pathErr.Err
+	}
+	return SkippedFile{Path: mg.toRelativePath(parseErr.Path), Reason: reason.Error()}
+}
+
+// skippedSection은 "skipped" 템플릿이 없을 때 출력 끝에 넣는 건너뛴 파일 목록
+func skippedSection(skipped []SkippedFile) string {
+	var sb strings.Builder
+	sb.WriteString("## Skipped files\n\n")
+	for _, file := range skipped {
+		sb.WriteString(fmt.Sprintf("- `%s`: %s\n", file.Path, file.Reason))
+	}
+	sb.WriteString("\n")
+	return sb.String()
+}
+
+// renderSkipped는 건너뛴 파일 목록을 "skipped" 템플릿 또는 기본 형식으로 렌더링 (없으면 빈 문자열)
+func (mg *markdownGenerator) renderSkipped(data TemplateData) (string, error) {
+	if len(data.Skipped) == 0 {
+		return "", nil
+	}
+	if mg.processor.HasTemplate(SkippedTemplateName) {
+		return mg.processor.ExecuteTemplate(SkippedTemplateName, data)
+	}
+	return skippedSection(data.Skipped), nil
+}
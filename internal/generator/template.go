@@ -2,46 +2,153 @@ package generator
 
 import (
 	"bytes"
+	"fmt"
 	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
 	"text/template"
+	"time"
+)
+
+// 섹션 단위 렌더링에 사용하는 템플릿 이름
+const (
+	HeaderTemplateName  = "header"
+	FileTemplateName    = "file"
+	FooterTemplateName  = "footer"
+	SkippedTemplateName = "skipped" // 건너뛴 파일 목록 (없으면 기본 "## Skipped files" 목록)
 )
 
 // 템플릿 처리기 구조체
 type templateProcessor struct {
-	tmpl *template.Template
+	tmpl   *template.Template
+	source string // 에러 위치 표시용 원본 템플릿
+}
+
+// TemplateError는 템플릿 파싱/실행 에러와 문제가 된 줄을 나타냄
+type TemplateError struct {
+	Line    int    // 0이면 위치를 알 수 없음
+	Excerpt string // 문제가 된 줄의 내용
+	Err     error
+}
+
+func (e *TemplateError) Error() string {
+	if e.Line == 0 {
+		return fmt.Sprintf("템플릿 에러: %v", e.Err)
+	}
+	return fmt.Sprintf("템플릿 에러 (줄 %d): %v\n  %d | %s", e.Line, e.Err, e.Line, e.Excerpt)
+}
+
+func (e *TemplateError) Unwrap() error {
+	return e.Err
+}
+
+// text/template 에러 메시지의 "template: 이름:줄:" 부분
+var templateLinePattern = regexp.MustCompile(`^template: [^:]*:(\d+):`)
+
+// newTemplateError는 text/template 에러에서 줄 번호를 찾아 TemplateError로 변환
+func newTemplateError(source string, err error) error {
+	te := &TemplateError{Err: err}
+
+	match := templateLinePattern.FindStringSubmatch(err.Error())
+	if match == nil {
+		return te
+	}
+	line, _ := strconv.Atoi(match[1])
+	lines := strings.Split(source, "\n")
+	if line >= 1 && line <= len(lines) {
+		te.Line = line
+		te.Excerpt = lines[line-1]
+	}
+	return te
 }
 
 // 템플릿 데이터 구조체
 type FileData struct {
 	Path      string
+	Dir       string // 파일이 있는 디렉토리 (루트는 ".")
 	Content   string
 	Extension string
+	Language  string // 코드 펜스에 사용할 언어 식별자
+	Fence     string // 내용 안의 백틱보다 긴 코드 펜스
+	Anchor    string // 목차에서 연결하는 헤딩 앵커
+	Size      int64  // 디스크에 있는 파일의 바이트 크기 (인코딩, 줄바꿈 변환 전)
+	Lines     int    // 줄 수 (크기 제한으로 잘렸으면 잘리기 전 줄 수)
+	ModTime   time.Time
+	SHA256    string // 디스크에 있는 원본 바이트의 해시
+	Binary    string // 바이너리 파일 내용을 넣은 형식 (base64, hex), 텍스트 파일이면 빈 문자열
+	Encoding  string // 텍스트 파일의 원본 인코딩 (UTF-8, UTF-8-BOM, UTF-16LE, UTF-16BE, EUC-KR, Windows-1252)
+	Truncated bool   // 파일별 크기 제한으로 내용이 잘렸는지 여부
 }
 
 type TemplateData struct {
 	ProjectName string
 	Structure   string
+	TOC         string // 파일별 앵커 링크 목차
 	Files       []FileData
+	Skipped     []SkippedFile // -keep-going으로 건너뛴 파일 (footer와 skipped 템플릿에서는 전체 목록)
 }
 
 // 생성자 함수
 func NewTemplateProcessor(templateStr string) (*templateProcessor, error) {
-	tmpl, err := template.New("markdown").Parse(templateStr)
+	tmpl, err := template.New("markdown").Funcs(templateFuncs).Parse(templateStr)
 	if err != nil {
-		return nil, err
+		return nil, newTemplateError(templateStr, err)
 	}
-	return &templateProcessor{tmpl: tmpl}, nil
+	return &templateProcessor{tmpl: tmpl, source: templateStr}, nil
 }
 
 // 템플릿 실행
 func (tp *templateProcessor) Execute(data TemplateData) (string, error) {
 	var buf bytes.Buffer
 	if err := tp.tmpl.Execute(&buf, data); err != nil {
-		return "", err
+		return "", newTemplateError(tp.source, err)
 	}
 	return buf.String(), nil
 }
 
+// HasSections는 템플릿이 파일 단위 "file" 템플릿을 정의했는지 확인
+func (tp *templateProcessor) HasSections() bool {
+	return tp.HasTemplate(FileTemplateName)
+}
+
+// HasTemplate은 이름이 지정된 템플릿이 정의되어 있는지 확인
+func (tp *templateProcessor) HasTemplate(name string) bool {
+	return tp.tmpl.Lookup(name) != nil
+}
+
+// ExecuteTemplate은 이름이 지정된 템플릿을 실행 (정의되지 않았으면 빈 문자열)
+func (tp *templateProcessor) ExecuteTemplate(name string, data interface{}) (string, error) {
+	if tp.tmpl.Lookup(name) == nil {
+		return "", nil
+	}
+	var buf bytes.Buffer
+	if err := tp.tmpl.ExecuteTemplate(&buf, name, data); err != nil {
+		return "", newTemplateError(tp.source, err)
+	}
+	return buf.String(), nil
+}
+
+// codeFence는 내용에 있는 가장 긴 백틱 연속보다 긴 코드 펜스를 반환 (최소 3개)
+func codeFence(content string) string {
+	longest, run := 0, 0
+	for i := 0; i < len(content); i++ {
+		if content[i] == '`' {
+			run++
+			if run > longest {
+				longest = run
+			}
+		} else {
+			run = 0
+		}
+	}
+
+	if longest < 3 {
+		return "```"
+	}
+	return strings.Repeat("`", longest+1)
+}
+
 func (tp *templateProcessor) getExtension(path string) string {
 	ext := filepath.Ext(path)
 	if ext != "" {
@@ -12,36 +12,26 @@ type CodeIgnorePattern struct {
 	pattern     string
 	isNegative  bool
 	isDirectory bool
+	isAnchored  bool // 슬래시가 있는 패턴은 기준 디렉토리 상대 경로 전체와 매칭
+	kind        matchKind
+	literal     string // kind가 matchGlob이 아닐 때 비교할 문자열
+	text        string // 파일에 적힌 그대로의 패턴
+	source      string // 패턴이 정의된 파일 (AddPattern으로 추가하면 빈 문자열)
+	line        int    // 패턴이 정의된 줄 번호 (1부터)
 }
 
-// IsMatch는 패턴이 주어진 경로와 매칭되는지 확인합니다
+// IsMatch는 패턴이 주어진 경로(기준 디렉토리 상대, 슬래시 구분)와 매칭되는지 확인합니다
+// 디렉토리 전용 패턴인지는 확인하지 않습니다
 func (p *CodeIgnorePattern) IsMatch(path string) bool {
 	if path == "" {
 		return false
 	}
 
-	pattern := p.pattern
-	if !strings.HasPrefix(pattern, "/") {
-		pattern = "**/" + pattern
+	// 슬래시가 없는 패턴은 어느 깊이에서든 이름과 매칭
+	if !p.isAnchored {
+		path = path[strings.LastIndex(path, "/")+1:]
 	}
-	pattern = strings.TrimPrefix(pattern, "/")
-
-	if p.isDirectory {
-		pattern += "/**"
-	}
-
-	matched, err := filepath.Match(pattern, path)
-	if err != nil {
-		return false
-	}
-
-	if strings.Contains(pattern, "**") {
-		pathParts := strings.Split(path, "/")
-		patternParts := strings.Split(pattern, "/")
-		return matchWithDoublestar(patternParts, pathParts)
-	}
-
-	return matched
+	return p.matchText(path)
 }
 
 func (p *CodeIgnorePattern) IsNegative() bool {
@@ -52,122 +42,203 @@ func (p *CodeIgnorePattern) IsDirectory() bool {
 	return p.isDirectory
 }
 
+func (p *CodeIgnorePattern) Source() string {
+	return p.source
+}
+
+func (p *CodeIgnorePattern) Line() int {
+	return p.line
+}
+
+// String은 파일에 적힌 그대로의 패턴을 반환합니다
+func (p *CodeIgnorePattern) String() string {
+	return p.text
+}
+
 // CodeIgnore는 .codeignore 파일의 패턴들을 관리합니다
+// 하위 디렉토리의 .codeignore는 그 디렉토리 기준으로 적용되며 상위 규칙보다 우선합니다
 type CodeIgnore struct {
-	patterns []Pattern
+	patterns []Pattern // 루트 디렉토리 기준 패턴
 	root     string
+	rootSet  *ruleSet      // patterns로 만든 규칙 묶음 (패턴이 바뀌면 다시 생성)
+	dirs     *dirRuleCache // 하위 디렉토리별 .codeignore 캐시
+	engine   *ruleEngine
 }
 
 // NewCodeIgnore는 주어진 경로의 .codeignore 파일을 읽어서 새로운 CodeIgnore 객체를 생성합니다
 func NewCodeIgnore(path string) (Ignorer, error) {
-	ci := &CodeIgnore{
-		root: filepath.Dir(path),
-	}
+	ci := newCodeIgnore(filepath.Dir(path), filepath.Base(path))
 	if err := ci.LoadFromFile(path); err != nil {
 		return nil, err
 	}
 	return ci, nil
 }
 
+// NewCodeIgnoreFromDir는 root와 하위 디렉토리의 .codeignore 파일들을 사용하는 CodeIgnore 객체를 생성합니다
+// root에 .codeignore가 없어도 하위 디렉토리의 파일은 적용됩니다
+func NewCodeIgnoreFromDir(root string) (Ignorer, error) {
+	ci := newCodeIgnore(root, ".codeignore")
+	err := ci.LoadFromFile(filepath.Join(root, ".codeignore"))
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	return ci, nil
+}
+
+func newCodeIgnore(root string, fileName string) *CodeIgnore {
+	ci := &CodeIgnore{
+		root: root,
+		dirs: newDirRuleCache(fileName),
+	}
+	ci.engine = newRuleEngine(root, false, ci.ruleSets)
+	return ci
+}
+
+// ruleSets는 dir 안의 경로에 적용되는 규칙을 반환합니다 (루트 규칙 다음에 하위 .codeignore 규칙)
+func (ci *CodeIgnore) ruleSets(dir string) []*ruleSet {
+	if ci.rootSet == nil {
+		ci.rootSet = &ruleSet{base: ci.root, patterns: ci.patterns}
+	}
+	sets := []*ruleSet{ci.rootSet}
+	return append(sets, ci.dirs.chain(ci.root, dir, false)...)
+}
+
+// SetLegacyNegation은 부정 디렉토리 패턴(!dir/)이 디렉토리 안의 경로도 다시 포함할지 설정합니다
+// 기본값은 git과 같이 디렉토리만 다시 포함하고, true면 "*.log"와 "!logs/"에서 logs/a.log도 포함하는 이전 동작을 따릅니다
+func (ci *CodeIgnore) SetLegacyNegation(enable bool) {
+	ci.engine.setNegatedContents(enable)
+}
+
+// patternsChanged는 패턴이 추가되었을 때 캐시를 비웁니다
+func (ci *CodeIgnore) patternsChanged() {
+	ci.rootSet = nil
+	ci.engine.reset()
+}
+
 // LoadFromFile은 .codeignore 파일을 읽어서 패턴을 로드합니다
 func (ci *CodeIgnore) LoadFromFile(path string) error {
-	file, err := os.Open(path)
+	patterns, err := readPatternFile(path)
 	if err != nil {
 		return err
 	}
+	ci.patterns = append(ci.patterns, patterns...)
+	ci.patternsChanged()
+	return nil
+}
+
+// AddPattern은 새로운 무시 패턴을 추가합니다
+func (ci *CodeIgnore) AddPattern(pattern string) error {
+	if p, ok := parseLine(pattern); ok {
+		ci.patterns = append(ci.patterns, p)
+		ci.patternsChanged()
+	}
+	return nil
+}
+
+// readPatternFile은 무시 파일을 읽어서 주석과 빈 줄을 제외한 패턴들을 반환합니다
+func readPatternFile(path string) ([]Pattern, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
 	defer file.Close()
 
+	var patterns []Pattern
 	scanner := bufio.NewScanner(file)
-	for scanner.Scan() {
-		pattern := strings.TrimSpace(scanner.Text())
-		if pattern == "" || strings.HasPrefix(pattern, "#") {
-			continue
+	for lineNum := 1; scanner.Scan(); lineNum++ {
+		line := scanner.Text()
+		if lineNum == 1 {
+			line = strings.TrimPrefix(line, "\uFEFF")
 		}
-		if err := ci.AddPattern(pattern); err != nil {
-			return err
+		if p, ok := parseLine(line); ok {
+			p.source, p.line = path, lineNum
+			patterns = append(patterns, p)
 		}
 	}
-	return scanner.Err()
+	return patterns, scanner.Err()
 }
 
-// AddPattern은 새로운 무시 패턴을 추가합니다
-func (ci *CodeIgnore) AddPattern(pattern string) error {
-	p := &CodeIgnorePattern{
-		pattern: pattern,
+// parseLine은 무시 파일의 한 줄을 패턴으로 해석합니다 (주석이나 빈 줄이면 ok가 false)
+func parseLine(line string) (*CodeIgnorePattern, bool) {
+	line = strings.TrimSuffix(line, "\r")
+	if line == "" || strings.HasPrefix(line, "#") {
+		return nil, false
+	}
+	line = trimTrailingSpaces(line)
+	if line == "" {
+		return nil, false
+	}
+	return parsePattern(line), true
+}
+
+// trimTrailingSpaces는 백슬래시로 이스케이프되지 않은 끝 공백을 제거합니다
+func trimTrailingSpaces(line string) string {
+	lastSpace := -1
+	for i := 0; i < len(line); i++ {
+		switch line[i] {
+		case ' ':
+			if lastSpace < 0 {
+				lastSpace = i
+			}
+		case '\\':
+			i++
+			if i >= len(line) {
+				return line
+			}
+			lastSpace = -1
+		default:
+			lastSpace = -1
+		}
 	}
+	if lastSpace >= 0 {
+		return line[:lastSpace]
+	}
+	return line
+}
+
+// ParsePattern은 gitignore 문법의 패턴 하나를 해석합니다 (ignore 파일 밖에서 경로를 고를 때 사용)
+func ParsePattern(pattern string) Pattern {
+	return parsePattern(pattern)
+}
+
+// parsePattern은 한 줄의 패턴에서 부정(!), 디렉토리(/), 위치 고정 여부를 해석합니다
+// "\!"와 "\#"처럼 이스케이프된 문자는 wildmatch에서 그대로 매칭됩니다
+func parsePattern(pattern string) *CodeIgnorePattern {
+	p := &CodeIgnorePattern{text: pattern}
 
 	if strings.HasPrefix(pattern, "!") {
 		p.isNegative = true
 		pattern = strings.TrimPrefix(pattern, "!")
-		p.pattern = pattern
 	}
 
 	if strings.HasSuffix(pattern, "/") {
 		p.isDirectory = true
 		pattern = strings.TrimSuffix(pattern, "/")
-		p.pattern = pattern
 	}
 
-	ci.patterns = append(ci.patterns, p)
-	return nil
+	// 앞이나 중간에 슬래시가 있으면 기준 디렉토리에 고정
+	if strings.Contains(pattern, "/") {
+		p.isAnchored = true
+		pattern = strings.TrimPrefix(pattern, "/")
+	}
+
+	p.pattern = pattern
+	p.compile()
+	return p
 }
 
 // ShouldIgnore는 주어진 경로가 무시되어야 하는지 확인합니다
+// 부정 디렉토리 패턴(!dir/)은 디렉토리만 다시 포함하며, 다른 규칙으로 무시된 디렉토리 안의 파일은 그대로 무시됩니다 (git과 동일)
 func (ci *CodeIgnore) ShouldIgnore(path string) bool {
-	relPath, err := filepath.Rel(ci.root, path)
-	if err != nil {
-		return false
-	}
-
-	relPath = filepath.ToSlash(relPath)
-
-	var (
-		isMatched bool
-		lastMatch Pattern
-	)
-
-	for _, pattern := range ci.patterns {
-		if pattern.IsMatch(relPath) {
-			isMatched = true
-			lastMatch = pattern
-		}
-	}
-
-	if !isMatched || lastMatch == nil {
+	lastMatch := ci.MatchingPattern(path)
+	if lastMatch == nil {
 		return false
 	}
 
 	return !lastMatch.IsNegative()
 }
 
-// matchWithDoublestar는 ** 패턴을 포함한 매칭을 처리합니다
-func matchWithDoublestar(patternParts, pathParts []string) bool {
-	patternIdx := 0
-	pathIdx := 0
-
-	for patternIdx < len(patternParts) && pathIdx < len(pathParts) {
-		if patternParts[patternIdx] == "**" {
-			patternIdx++
-			if patternIdx >= len(patternParts) {
-				return true
-			}
-			for ; pathIdx < len(pathParts); pathIdx++ {
-				if match, _ := filepath.Match(patternParts[patternIdx], pathParts[pathIdx]); match {
-					if matchWithDoublestar(patternParts[patternIdx:], pathParts[pathIdx:]) {
-						return true
-					}
-				}
-			}
-			return false
-		}
-
-		matched, _ := filepath.Match(patternParts[patternIdx], pathParts[pathIdx])
-		if !matched {
-			return false
-		}
-		patternIdx++
-		pathIdx++
-	}
-
-	return patternIdx == len(patternParts) && pathIdx == len(pathParts)
+// MatchingPattern은 경로의 무시 여부를 결정한 패턴을 반환합니다 (매칭이 없으면 nil)
+func (ci *CodeIgnore) MatchingPattern(path string) Pattern {
+	return ci.engine.match(path)
 }
@@ -319,6 +319,8 @@ web/
 	if err != nil {
 		t.Fatalf("NewCodeIgnore 실패: %v", err)
 	}
+	// 부정 디렉토리 패턴으로 디렉토리 안의 파일까지 다시 포함하는 이전 동작
+	ignorer.(*CodeIgnore).SetLegacyNegation(true)
 
 	// 각 파일에 대해 무시 규칙 테스트
 	for path, shouldIgnore := range testFiles {
@@ -377,6 +379,8 @@ func TestNestedDirectoryPatterns(t *testing.T) {
 	if err != nil {
 		t.Fatalf("NewCodeIgnore 실패: %v", err)
 	}
+	// 부정 디렉토리 패턴으로 디렉토리 안의 파일까지 다시 포함하는 이전 동작
+	ignorer.(*CodeIgnore).SetLegacyNegation(true)
 
 	// 각 파일에 대해 무시 규칙 테스트
 	for path, shouldIgnore := range testFiles {
@@ -388,3 +392,248 @@ func TestNestedDirectoryPatterns(t *testing.T) {
 		}
 	}
 }
+
+// TestHierarchicalCodeIgnore는 하위 디렉토리 .codeignore의 우선순위를 테스트
+func TestHierarchicalCodeIgnore(t *testing.T) {
+	tmpDir := t.TempDir()
+
+	ignoreFiles := map[string]string{
+		".codeignore":               "*.log\ngenerated/\n/config.yaml\n",
+		"team-a/.codeignore":        "!debug.log\n/config.yaml\n*.tmp\n",
+		"team-a/sub/.codeignore":    "debug.log\n!*.tmp\n",
+		"team-b/.codeignore":        "!generated/\n",
+		"team-b/legacy/.codeignore": "*\n!*.go\n",
+	}
+	for path, content := range ignoreFiles {
+		fullPath := filepath.Join(tmpDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("디렉토리 생성 실패: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatalf(".codeignore 파일 생성 실패: %v", err)
+		}
+	}
+
+	ignorer, err := NewCodeIgnoreFromDir(tmpDir)
+	if err != nil {
+		t.Fatalf("NewCodeIgnoreFromDir 실패: %v", err)
+	}
+
+	testFiles := map[string]bool{
+		"app.log":                   true,  // 루트 규칙
+		"config.yaml":               true,  // 루트에 고정된 패턴
+		"team-a/config.yaml":        true,  // 하위 파일 기준으로 고정된 패턴
+		"team-a/debug.log":          false, // 하위 부정 패턴이 루트 규칙보다 우선
+		"team-a/error.log":          true,  // 루트 규칙 유지
+		"team-a/cache.tmp":          true,  // 하위 규칙
+		"team-a/sub/debug.log":      true,  // 더 깊은 규칙이 다시 무시
+		"team-a/sub/cache.tmp":      false, // 더 깊은 부정 패턴
+		"team-a/sub/config.yaml":    false, // /config.yaml은 team-a에만 적용
+		"generated/api.go":          true,
+		"team-b/generated/api.go":   false, // 디렉토리 패턴 부정
+		"team-b/legacy/main.go":     false,
+		"team-b/legacy/README.md":   true,
+		"team-b/legacy/nested/a.go": true, // 상위 디렉토리가 무시되면 다시 포함할 수 없음
+	}
+	for path, shouldIgnore := range testFiles {
+		got := ignorer.ShouldIgnore(filepath.Join(tmpDir, path))
+		if got != shouldIgnore {
+			t.Errorf("경로 %q에 대한 결과가 잘못됨. got %v, want %v", path, got, shouldIgnore)
+		}
+	}
+}
+
+// TestNegatedDirectoryPattern은 부정 디렉토리 패턴이 다른 규칙으로 무시된 파일을 다시 포함하지 않는지 테스트 (git과 동일)
+func TestNegatedDirectoryPattern(t *testing.T) {
+	tmpDir := t.TempDir()
+	for _, path := range []string{"logs/a.log", "logs/a.txt", "app.log"} {
+		fullPath := filepath.Join(tmpDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("디렉토리 생성 실패: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte("test"), 0644); err != nil {
+			t.Fatalf("파일 생성 실패: %v", err)
+		}
+	}
+	codeignorePath := filepath.Join(tmpDir, ".codeignore")
+	if err := os.WriteFile(codeignorePath, []byte("*.log\n!logs/\n"), 0644); err != nil {
+		t.Fatalf(".codeignore 파일 생성 실패: %v", err)
+	}
+
+	tests := []struct {
+		legacy bool
+		want   map[string]bool
+	}{
+		{false, map[string]bool{"logs": false, "logs/a.log": true, "logs/a.txt": false, "app.log": true}},
+		{true, map[string]bool{"logs": false, "logs/a.log": false, "logs/a.txt": false, "app.log": true}},
+	}
+	for _, tt := range tests {
+		ignorer, err := NewCodeIgnore(codeignorePath)
+		if err != nil {
+			t.Fatalf("NewCodeIgnore 실패: %v", err)
+		}
+		ignorer.(*CodeIgnore).SetLegacyNegation(tt.legacy)
+		for path, shouldIgnore := range tt.want {
+			if got := ignorer.ShouldIgnore(filepath.Join(tmpDir, path)); got != shouldIgnore {
+				t.Errorf("legacy=%v: 경로 %q에 대한 결과가 잘못됨. got %v, want %v", tt.legacy, path, got, shouldIgnore)
+			}
+		}
+	}
+}
+
+// TestCodeIgnoreFromDirWithoutRootFile은 루트 .codeignore 없이 하위 파일만 있는 경우를 테스트
+func TestCodeIgnoreFromDirWithoutRootFile(t *testing.T) {
+	tmpDir := t.TempDir()
+	subDir := filepath.Join(tmpDir, "pkg")
+	if err := os.MkdirAll(subDir, 0755); err != nil {
+		t.Fatalf("디렉토리 생성 실패: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(subDir, ".codeignore"), []byte("*.pb.go\n"), 0644); err != nil {
+		t.Fatalf(".codeignore 파일 생성 실패: %v", err)
+	}
+
+	ignorer, err := NewCodeIgnoreFromDir(tmpDir)
+	if err != nil {
+		t.Fatalf("NewCodeIgnoreFromDir 실패: %v", err)
+	}
+
+	if !ignorer.ShouldIgnore(filepath.Join(subDir, "api.pb.go")) {
+		t.Error("하위 .codeignore 패턴이 적용되지 않음")
+	}
+	if ignorer.ShouldIgnore(filepath.Join(tmpDir, "api.pb.go")) {
+		t.Error("하위 .codeignore 패턴이 상위 디렉토리에 적용됨")
+	}
+}
+
+// TestGitignoreSemantics는 gitignore 문서에 정의된 패턴 규칙을 테스트
+func TestGitignoreSemantics(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		paths    map[string]bool
+	}{
+		{
+			name:     "중간 슬래시는 위치 고정",
+			patterns: []string{"doc/frotz"},
+			paths: map[string]bool{
+				"doc/frotz":     true,
+				"a/doc/frotz":   false,
+				"doc/frotz.txt": false,
+			},
+		},
+		{
+			name:     "슬래시 없는 디렉토리 패턴은 모든 깊이",
+			patterns: []string{"frotz/"},
+			paths: map[string]bool{
+				"frotz/a.go":   true,
+				"a/frotz/b.go": true,
+			},
+		},
+		{
+			name:     "디렉토리 전용 패턴은 파일과 매칭되지 않음",
+			patterns: []string{"cache/"},
+			paths: map[string]bool{
+				"cache":     false,
+				"src/cache": false,
+			},
+		},
+		{
+			name:     "이스케이프된 # 과 !",
+			patterns: []string{`\#notes.md`, `\!important.txt`},
+			paths: map[string]bool{
+				"#notes.md":      true,
+				"notes.md":       false,
+				"!important.txt": true,
+				"important.txt":  false,
+			},
+		},
+		{
+			name:     "끝 공백은 무시",
+			patterns: []string{"trailing.txt   "},
+			paths: map[string]bool{
+				"trailing.txt": true,
+			},
+		},
+		{
+			name:     "a/**/b는 0개 이상의 디렉토리와 매칭",
+			patterns: []string{"a/**/b.go"},
+			paths: map[string]bool{
+				"a/b.go":     true,
+				"a/x/b.go":   true,
+				"a/x/y/b.go": true,
+				"x/a/b.go":   false,
+			},
+		},
+		{
+			name:     "끝의 /**는 안의 모든 경로와 매칭",
+			patterns: []string{"abc/**"},
+			paths: map[string]bool{
+				"abc/a.go":   true,
+				"abc/x/a.go": true,
+				"abcd/a.go":  false,
+			},
+		},
+		{
+			name:     "상위 디렉토리가 무시되면 다시 포함할 수 없음",
+			patterns: []string{"vendor/", "!vendor/keep.go", "build/*", "!build/keep.go"},
+			paths: map[string]bool{
+				"vendor/keep.go":  true,
+				"vendor/other.go": true,
+				"build/keep.go":   false, // build/*는 디렉토리가 아니라 안의 파일을 무시
+				"build/other.go":  true,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tmpDir := t.TempDir()
+			codeignorePath := filepath.Join(tmpDir, ".codeignore")
+			content := ""
+			for _, pattern := range tt.patterns {
+				content += pattern + "\n"
+			}
+			if err := os.WriteFile(codeignorePath, []byte(content), 0644); err != nil {
+				t.Fatalf(".codeignore 파일 생성 실패: %v", err)
+			}
+
+			ignorer, err := NewCodeIgnore(codeignorePath)
+			if err != nil {
+				t.Fatalf("NewCodeIgnore 실패: %v", err)
+			}
+
+			for path, shouldIgnore := range tt.paths {
+				fullPath := filepath.Join(tmpDir, path)
+				if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+					t.Fatalf("디렉토리 생성 실패: %v", err)
+				}
+				if err := os.WriteFile(fullPath, []byte("test"), 0644); err != nil {
+					t.Fatalf("테스트 파일 생성 실패: %v", err)
+				}
+
+				if got := ignorer.ShouldIgnore(fullPath); got != shouldIgnore {
+					t.Errorf("패턴 %q에 대해 경로 %q의 결과가 잘못됨. got %v, want %v",
+						tt.patterns, path, got, shouldIgnore)
+				}
+			}
+		})
+	}
+}
+
+// TestTrimTrailingSpaces는 이스케이프되지 않은 끝 공백 제거를 테스트
+func TestTrimTrailingSpaces(t *testing.T) {
+	tests := map[string]string{
+		"foo   ":    "foo",
+		`foo\ `:     `foo\ `,
+		`foo\  `:    `foo\ `,
+		`foo\ \  `:  `foo\ \ `,
+		" foo":      " foo",
+		`foo\`:      `foo\`,
+		"foo bar  ": "foo bar",
+	}
+	for line, want := range tests {
+		if got := trimTrailingSpaces(line); got != want {
+			t.Errorf("trimTrailingSpaces(%q) = %q, want %q", line, got, want)
+		}
+	}
+}
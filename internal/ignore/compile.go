@@ -0,0 +1,103 @@
+package ignore
+
+import "strings"
+
+// matchKind는 패턴을 wildmatch 없이 비교할 수 있는 형태로 분류한 것입니다
+type matchKind int
+
+const (
+	matchGlob    matchKind = iota // wildmatch로 비교
+	matchLiteral                  // 특수 문자가 없는 패턴 (문자열 비교)
+	matchSuffix                   // "*.log"처럼 '*' 하나로 시작하는 패턴
+	matchPrefix                   // "build-*"처럼 '*' 하나로 끝나는 패턴
+)
+
+// hasGlobSpecial은 wildmatch 특수 문자가 있는지 확인합니다
+func hasGlobSpecial(s string) bool {
+	return strings.ContainsAny(s, `*?[\`)
+}
+
+// compile은 패턴 문자열을 분석해서 비교 방식을 정합니다
+func (p *CodeIgnorePattern) compile() {
+	pattern := p.pattern
+	switch {
+	case !hasGlobSpecial(pattern):
+		p.kind, p.literal = matchLiteral, pattern
+	case strings.HasPrefix(pattern, "*") && !hasGlobSpecial(pattern[1:]):
+		p.kind, p.literal = matchSuffix, pattern[1:]
+	case len(pattern) > 1 && strings.HasSuffix(pattern, "*") && !hasGlobSpecial(pattern[:len(pattern)-1]):
+		p.kind, p.literal = matchPrefix, pattern[:len(pattern)-1]
+	default:
+		p.kind, p.literal = matchGlob, ""
+	}
+}
+
+// matchText는 컴파일된 방식으로 패턴과 문자열을 비교합니다 ('*'는 '/'와 매칭되지 않음)
+func (p *CodeIgnorePattern) matchText(text string) bool {
+	switch p.kind {
+	case matchLiteral:
+		return text == p.literal
+	case matchSuffix:
+		return strings.HasSuffix(text, p.literal) &&
+			!strings.Contains(text[:len(text)-len(p.literal)], "/")
+	case matchPrefix:
+		return strings.HasPrefix(text, p.literal) &&
+			!strings.Contains(text[len(p.literal):], "/")
+	}
+	return wildmatch(p.pattern, text)
+}
+
+// patternIndex는 규칙 묶음의 패턴 번호를 이름, 확장자, 첫 경로 요소로 나눈 색인입니다
+// 경로마다 이름, 확장자, 첫 경로 요소가 같은 패턴과 나머지 패턴만 비교하면 됩니다
+type patternIndex struct {
+	byName      map[string][]int // 위치 고정이 아닌 literal 패턴 (키는 이름)
+	byExt       map[string][]int // 위치 고정이 아닌 "*.ext" 패턴 (키는 ".ext")
+	byFirstDir  map[string][]int // 첫 경로 요소가 일반 문자열인 위치 고정 패턴 (키는 첫 요소)
+	others      []int            // 그 밖의 패턴
+	negatedDirs []int            // 부정 디렉토리 패턴 (디렉토리 안의 경로에도 적용할 때 사용)
+}
+
+func newPatternIndex(patterns []Pattern) *patternIndex {
+	idx := &patternIndex{
+		byName:     make(map[string][]int),
+		byExt:      make(map[string][]int),
+		byFirstDir: make(map[string][]int),
+	}
+
+	for i, pattern := range patterns {
+		if pattern.IsNegative() && pattern.IsDirectory() {
+			idx.negatedDirs = append(idx.negatedDirs, i)
+		}
+
+		cp, ok := pattern.(*CodeIgnorePattern)
+		switch {
+		case !ok:
+			idx.others = append(idx.others, i)
+		case cp.isAnchored:
+			first, _, _ := strings.Cut(cp.pattern, "/")
+			if hasGlobSpecial(first) {
+				idx.others = append(idx.others, i)
+			} else {
+				idx.byFirstDir[first] = append(idx.byFirstDir[first], i)
+			}
+		case cp.kind == matchLiteral:
+			idx.byName[cp.literal] = append(idx.byName[cp.literal], i)
+		case cp.kind == matchSuffix && strings.LastIndex(cp.literal, ".") == 0:
+			idx.byExt[cp.literal] = append(idx.byExt[cp.literal], i)
+		default:
+			idx.others = append(idx.others, i)
+		}
+	}
+	return idx
+}
+
+// candidates는 relPath와 매칭될 수 있는 패턴 번호 목록들을 반환합니다
+func (idx *patternIndex) candidates(relPath string) [4][]int {
+	name := relPath[strings.LastIndex(relPath, "/")+1:]
+	var ext string
+	if dot := strings.LastIndex(name, "."); dot >= 0 {
+		ext = name[dot:]
+	}
+	first, _, _ := strings.Cut(relPath, "/")
+	return [4][]int{idx.byName[name], idx.byExt[ext], idx.byFirstDir[first], idx.others}
+}
@@ -0,0 +1,230 @@
+package ignore
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestCompiledPatternMatchesWildmatch는 빠른 비교 방식이 wildmatch와 같은 결과를 내는지 테스트
+func TestCompiledPatternMatchesWildmatch(t *testing.T) {
+	patterns := map[string]matchKind{
+		"main.go":    matchLiteral,
+		"*.log":      matchSuffix,
+		"*":          matchSuffix,
+		"*_test.go":  matchSuffix,
+		"build-*":    matchPrefix,
+		"src/gen/*":  matchPrefix,
+		"*.[ch]":     matchGlob,
+		`foo\*`:      matchGlob,
+		"**/tmp":     matchGlob,
+		"a?c":        matchGlob,
+		"src/**/*.g": matchGlob,
+	}
+	texts := []string{
+		"", "main.go", "a/main.go", "debug.log", ".log", "a/b.log", "x_test.go",
+		"build-", "build-1", "build-1/x", "src/gen/a", "src/gen/a/b", "src/gen/",
+		"a.c", "a.h", "foo*", "tmp", "x/tmp", "abc", "a/c", "src/a/b.g",
+	}
+
+	for pattern, kind := range patterns {
+		p := &CodeIgnorePattern{pattern: pattern}
+		p.compile()
+		if p.kind != kind {
+			t.Errorf("패턴 %q의 분류가 잘못됨. got %v, want %v", pattern, p.kind, kind)
+		}
+		for _, text := range texts {
+			if got, want := p.matchText(text), wildmatch(pattern, text); got != want {
+				t.Errorf("패턴 %q, 경로 %q: got %v, wildmatch %v", pattern, text, got, want)
+			}
+		}
+	}
+}
+
+// benchmarkRules는 큰 저장소에서 볼 수 있는 수백 개의 규칙을 만듭니다
+func benchmarkRules() []string {
+	var rules []string
+	for i := 0; i < 100; i++ {
+		rules = append(rules, fmt.Sprintf("*.ext%d", i))
+		rules = append(rules, fmt.Sprintf("generated%d.go", i))
+		rules = append(rules, fmt.Sprintf("cache%d/", i))
+	}
+	for i := 0; i < 50; i++ {
+		rules = append(rules, fmt.Sprintf("/pkg%d/**/fixtures/*.json", i))
+		rules = append(rules, fmt.Sprintf("!keep%d.ext%d", i, i))
+		rules = append(rules, fmt.Sprintf("tmp-%d-*", i))
+	}
+	return rules
+}
+
+// benchmarkPaths는 깊이가 다양한 경로를 만듭니다
+func benchmarkPaths(root string, n int) []string {
+	paths := make([]string, n)
+	for i := range paths {
+		dir := filepath.Join(root, fmt.Sprintf("pkg%d", i%60), fmt.Sprintf("sub%d", i%17), "internal")
+		paths[i] = filepath.Join(dir, fmt.Sprintf("file%d.ext%d", i, i%150))
+	}
+	return paths
+}
+
+// oldCodeIgnore는 패턴 컴파일, 색인, 캐시를 도입하기 전의 CodeIgnore.ShouldIgnore 구현입니다 (비교 기준)
+// 경로마다 하위 .codeignore 목록을 다시 만들고, 모든 상위 디렉토리에 모든 패턴을 wildmatch로 비교합니다
+type oldCodeIgnore struct {
+	patterns []Pattern
+	root     string
+	dirs     *dirRuleCache
+}
+
+func (ci *oldCodeIgnore) ShouldIgnore(path string) bool {
+	sets := []*ruleSet{{base: ci.root, patterns: ci.patterns}}
+	sets = append(sets, ci.dirs.chain(ci.root, filepath.Dir(path), false)...)
+
+	lastMatch := oldMatchPath(sets, ci.root, path)
+	if lastMatch == nil {
+		return false
+	}
+	return !lastMatch.IsNegative()
+}
+
+func oldMatchPath(sets []*ruleSet, top string, path string) Pattern {
+	var ancestors []string
+	for dir := filepath.Dir(path); oldIsUnder(top, dir); dir = filepath.Dir(dir) {
+		ancestors = append([]string{dir}, ancestors...)
+	}
+
+	isDirectory := func() bool { return true }
+	for _, dir := range ancestors {
+		if match := oldLastMatch(sets, dir, isDirectory); match != nil && !match.IsNegative() {
+			return match
+		}
+	}
+
+	var statDone, statIsDir bool
+	isDirectory = func() bool {
+		if !statDone {
+			info, err := os.Stat(path)
+			statIsDir = err == nil && info.IsDir()
+			statDone = true
+		}
+		return statIsDir
+	}
+	return oldLastMatch(sets, path, isDirectory)
+}
+
+func oldIsUnder(top string, path string) bool {
+	relPath, err := filepath.Rel(top, path)
+	if err != nil {
+		return false
+	}
+	return relPath != "." && relPath != ".." && !strings.HasPrefix(relPath, ".."+string(filepath.Separator))
+}
+
+func oldLastMatch(sets []*ruleSet, path string, isDirectory func() bool) Pattern {
+	var last Pattern
+	for _, rs := range sets {
+		if !oldIsUnder(rs.base, path) {
+			continue
+		}
+		relPath, _ := filepath.Rel(rs.base, path)
+		relPath = filepath.ToSlash(relPath)
+
+		for _, pattern := range rs.patterns {
+			if oldPatternMatches(pattern.(*CodeIgnorePattern), relPath, isDirectory) {
+				last = pattern
+			}
+		}
+	}
+	return last
+}
+
+func oldPatternMatches(p *CodeIgnorePattern, relPath string, isDirectory func() bool) bool {
+	if oldIsMatch(p, relPath) && (!p.isDirectory || isDirectory()) {
+		return true
+	}
+	if p.isNegative && p.isDirectory {
+		for i := strings.LastIndex(relPath, "/"); i > 0; i = strings.LastIndex(relPath[:i], "/") {
+			if oldIsMatch(p, relPath[:i]) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+func oldIsMatch(p *CodeIgnorePattern, path string) bool {
+	if path == "" {
+		return false
+	}
+	if !p.isAnchored {
+		path = path[strings.LastIndex(path, "/")+1:]
+	}
+	return wildmatch(p.pattern, path)
+}
+
+// TestOldCodeIgnoreMatches는 비교 기준 구현이 현재 구현과 같은 결과를 내는지 확인합니다
+func TestOldCodeIgnoreMatches(t *testing.T) {
+	root := t.TempDir()
+	ci := newCodeIgnore(root, ".codeignore")
+	ci.SetLegacyNegation(true)
+	old := &oldCodeIgnore{root: root, dirs: newDirRuleCache(".codeignore")}
+	for _, rule := range benchmarkRules() {
+		ci.AddPattern(rule)
+		p, _ := parseLine(rule)
+		old.patterns = append(old.patterns, p)
+	}
+	for _, path := range benchmarkPaths(root, 3000) {
+		if got, want := ci.ShouldIgnore(path), old.ShouldIgnore(path); got != want {
+			t.Errorf("ShouldIgnore(%q) = %v, 이전 구현 %v", path, got, want)
+		}
+	}
+}
+
+// BenchmarkShouldIgnore는 경로 하나의 무시 여부를 확인하는 시간을 측정합니다 (BenchmarkShouldIgnoreOld와 비교)
+func BenchmarkShouldIgnore(b *testing.B) {
+	root := b.TempDir()
+	ci := newCodeIgnore(root, ".codeignore")
+	for _, rule := range benchmarkRules() {
+		ci.AddPattern(rule)
+	}
+	paths := benchmarkPaths(root, 200000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ci.ShouldIgnore(paths[i%len(paths)])
+	}
+}
+
+// BenchmarkShouldIgnoreParallel은 여러 고루틴이 하나의 CodeIgnore를 함께 쓸 때를 측정합니다 (-cpu로 고루틴 수 지정)
+func BenchmarkShouldIgnoreParallel(b *testing.B) {
+	root := b.TempDir()
+	ci := newCodeIgnore(root, ".codeignore")
+	for _, rule := range benchmarkRules() {
+		ci.AddPattern(rule)
+	}
+	paths := benchmarkPaths(root, 200000)
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		for i := 0; pb.Next(); i++ {
+			ci.ShouldIgnore(paths[i%len(paths)])
+		}
+	})
+}
+
+// BenchmarkShouldIgnoreOld는 이전 구현(oldCodeIgnore)으로 같은 경로를 확인하는 시간을 측정합니다
+func BenchmarkShouldIgnoreOld(b *testing.B) {
+	root := b.TempDir()
+	ci := &oldCodeIgnore{root: root, dirs: newDirRuleCache(".codeignore")}
+	for _, rule := range benchmarkRules() {
+		p, _ := parseLine(rule)
+		ci.patterns = append(ci.patterns, p)
+	}
+	paths := benchmarkPaths(root, 200000)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ci.ShouldIgnore(paths[i%len(paths)])
+	}
+}
@@ -0,0 +1,154 @@
+package ignore
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// GitIgnore는 git과 같은 방식으로 .gitignore 규칙을 적용합니다
+// 전역 core.excludesFile, .git/info/exclude, 각 디렉토리의 .gitignore 순서로 우선순위가 높아집니다
+type GitIgnore struct {
+	root     string        // 문서화 루트 디렉토리
+	repoRoot string        // .git이 있는 저장소 루트 (없으면 root)
+	base     []*ruleSet    // 전역 규칙과 info/exclude, AddPattern/LoadFromFile로 추가된 규칙
+	dirs     *dirRuleCache // 디렉토리별 .gitignore 캐시
+	engine   *ruleEngine
+}
+
+// NewGitIgnore는 root가 속한 저장소의 gitignore 규칙을 사용하는 GitIgnore 객체를 생성합니다
+func NewGitIgnore(root string) (Ignorer, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return nil, err
+	}
+
+	gi := &GitIgnore{
+		root:     absRoot,
+		repoRoot: findRepoRoot(absRoot),
+		dirs:     newDirRuleCache(".gitignore"),
+	}
+	gi.engine = newRuleEngine(gi.repoRoot, false, gi.ruleSets)
+
+	if path := globalExcludesFile(absRoot); path != "" {
+		gi.loadBase(path, gi.repoRoot)
+	}
+	gi.loadBase(filepath.Join(gi.repoRoot, ".git", "info", "exclude"), gi.repoRoot)
+
+	return gi, nil
+}
+
+// loadBase는 파일이 있으면 base 기준 규칙으로 추가합니다
+func (gi *GitIgnore) loadBase(path string, base string) {
+	patterns, err := readPatternFile(path)
+	if err != nil {
+		return
+	}
+	gi.base = append(gi.base, &ruleSet{base: base, patterns: patterns})
+	gi.engine.reset()
+}
+
+// AddPattern은 저장소 루트 기준 패턴을 추가합니다
+func (gi *GitIgnore) AddPattern(pattern string) error {
+	if p, ok := parseLine(pattern); ok {
+		gi.base = append(gi.base, &ruleSet{base: gi.repoRoot, patterns: []Pattern{p}})
+		gi.engine.reset()
+	}
+	return nil
+}
+
+// LoadFromFile은 무시 파일을 읽어서 그 파일이 있는 디렉토리 기준 규칙으로 추가합니다
+func (gi *GitIgnore) LoadFromFile(path string) error {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return err
+	}
+	patterns, err := readPatternFile(absPath)
+	if err != nil {
+		return err
+	}
+	gi.base = append(gi.base, &ruleSet{base: filepath.Dir(absPath), patterns: patterns})
+	gi.engine.reset()
+	return nil
+}
+
+// ruleSets는 dir 안의 경로에 적용되는 규칙을 반환합니다
+// 저장소 루트부터 dir까지 내려가며 각 디렉토리의 .gitignore를 적용
+func (gi *GitIgnore) ruleSets(dir string) []*ruleSet {
+	sets := append([]*ruleSet{}, gi.base...)
+	return append(sets, gi.dirs.chain(gi.repoRoot, dir, true)...)
+}
+
+// gitDirPattern은 git이 항상 무시하는 .git 디렉토리를 나타냅니다
+var gitDirPattern = &CodeIgnorePattern{pattern: ".git", text: ".git", source: "(git)", kind: matchLiteral, literal: ".git"}
+
+// ShouldIgnore는 주어진 경로가 무시되어야 하는지 확인합니다
+func (gi *GitIgnore) ShouldIgnore(path string) bool {
+	lastMatch := gi.MatchingPattern(path)
+	if lastMatch == nil {
+		return false
+	}
+	return !lastMatch.IsNegative()
+}
+
+// MatchingPattern은 경로의 무시 여부를 결정한 패턴을 반환합니다 (매칭이 없으면 nil)
+func (gi *GitIgnore) MatchingPattern(path string) Pattern {
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return nil
+	}
+
+	// git은 .git 디렉토리를 항상 무시
+	if filepath.Base(absPath) == ".git" {
+		return gitDirPattern
+	}
+
+	return gi.engine.match(absPath)
+}
+
+// findRepoRoot는 dir부터 위로 올라가며 .git이 있는 디렉토리를 찾습니다 (없으면 dir)
+func findRepoRoot(dir string) string {
+	for current := dir; ; {
+		if _, err := os.Stat(filepath.Join(current, ".git")); err == nil {
+			return current
+		}
+		parent := filepath.Dir(current)
+		if parent == current {
+			return dir
+		}
+		current = parent
+	}
+}
+
+// globalExcludesFile은 사용자의 core.excludesFile 경로를 반환합니다
+// 설정이 없으면 git 기본값인 $XDG_CONFIG_HOME/git/ignore 또는 ~/.config/git/ignore를 사용
+func globalExcludesFile(dir string) string {
+	cmd := exec.Command("git", "config", "--get", "core.excludesFile")
+	cmd.Dir = dir
+	if out, err := cmd.Output(); err == nil {
+		if path := strings.TrimSpace(string(out)); path != "" {
+			return expandHome(path)
+		}
+	}
+
+	if xdg := os.Getenv("XDG_CONFIG_HOME"); xdg != "" {
+		return filepath.Join(xdg, "git", "ignore")
+	}
+	if home, err := os.UserHomeDir(); err == nil {
+		return filepath.Join(home, ".config", "git", "ignore")
+	}
+	return ""
+}
+
+// expandHome은 ~/로 시작하는 경로를 홈 디렉토리 기준으로 바꿉니다
+func expandHome(path string) string {
+	if !strings.HasPrefix(path, "~/") {
+		return path
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return path
+	}
+	return filepath.Join(home, path[2:])
+}
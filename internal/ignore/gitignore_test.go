@@ -0,0 +1,100 @@
+package ignore
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+)
+
+// writeFiles는 tmpDir 아래에 경로별 내용으로 파일을 생성
+func writeFiles(t *testing.T, tmpDir string, files map[string]string) {
+	t.Helper()
+	for path, content := range files {
+		fullPath := filepath.Join(tmpDir, path)
+		if err := os.MkdirAll(filepath.Dir(fullPath), 0755); err != nil {
+			t.Fatalf("디렉토리 생성 실패: %v", err)
+		}
+		if err := os.WriteFile(fullPath, []byte(content), 0644); err != nil {
+			t.Fatalf("파일 생성 실패: %v", err)
+		}
+	}
+}
+
+// TestGitIgnoreLevels는 디렉토리별 .gitignore와 info/exclude 적용을 테스트
+func TestGitIgnoreLevels(t *testing.T) {
+	tmpDir := t.TempDir()
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "xdg"))
+
+	writeFiles(t, tmpDir, map[string]string{
+		".git/info/exclude":    "*.secret\n",
+		".gitignore":           "node_modules/\n.env*\n*.log\n",
+		"pkg/.gitignore":       "!keep.log\n/generated.go\n",
+		"main.go":              "",
+		"debug.log":            "",
+		".env.local":           "",
+		"token.secret":         "",
+		"node_modules/a.js":    "",
+		"pkg/keep.log":         "",
+		"pkg/other.log":        "",
+		"pkg/generated.go":     "",
+		"pkg/sub/generated.go": "",
+	})
+
+	ignorer, err := NewGitIgnore(tmpDir)
+	if err != nil {
+		t.Fatalf("NewGitIgnore 실패: %v", err)
+	}
+
+	tests := map[string]bool{
+		"main.go":              false,
+		"debug.log":            true,
+		".env.local":           true,
+		"token.secret":         true,
+		"node_modules/a.js":    true,
+		".git":                 true,
+		"pkg/keep.log":         false, // 하위 .gitignore의 부정 패턴이 우선
+		"pkg/other.log":        true,
+		"pkg/generated.go":     true,
+		"pkg/sub/generated.go": false, // /로 시작하는 패턴은 .gitignore 위치에 고정
+	}
+	for path, want := range tests {
+		if got := ignorer.ShouldIgnore(filepath.Join(tmpDir, path)); got != want {
+			t.Errorf("경로 %q에 대한 결과가 잘못됨. got %v, want %v", path, got, want)
+		}
+	}
+}
+
+// TestGitIgnoreGlobalExcludes는 core.excludesFile 설정 사용을 테스트
+func TestGitIgnoreGlobalExcludes(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git 명령을 찾을 수 없음")
+	}
+
+	tmpDir := t.TempDir()
+	repoDir := filepath.Join(tmpDir, "repo")
+	t.Setenv("HOME", tmpDir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tmpDir, "xdg"))
+	t.Setenv("GIT_CONFIG_NOSYSTEM", "1")
+
+	writeFiles(t, tmpDir, map[string]string{
+		".gitconfig":       "[core]\n\texcludesFile = ~/global-ignore\n",
+		"global-ignore":    "*.swp\n",
+		"repo/.git/HEAD":   "",
+		"repo/main.go":     "",
+		"repo/main.go.swp": "",
+	})
+
+	ignorer, err := NewGitIgnore(repoDir)
+	if err != nil {
+		t.Fatalf("NewGitIgnore 실패: %v", err)
+	}
+
+	if ignorer.ShouldIgnore(filepath.Join(repoDir, "main.go")) {
+		t.Error("main.go가 무시됨")
+	}
+	if !ignorer.ShouldIgnore(filepath.Join(repoDir, "main.go.swp")) {
+		t.Error("전역 excludesFile 패턴이 적용되지 않음")
+	}
+}
@@ -2,14 +2,18 @@ package ignore
 
 // Pattern은 무시 패턴을 나타내는 인터페이스입니다
 type Pattern interface {
-	IsMatch(path string) bool
+	IsMatch(path string) bool // 기준 디렉토리 상대 경로(슬래시 구분)와 매칭되는지 확인
 	IsNegative() bool
 	IsDirectory() bool
+	Source() string // 패턴이 정의된 파일
+	Line() int      // 패턴이 정의된 줄 번호
+	String() string // 파일에 적힌 그대로의 패턴
 }
 
 // Ignorer는 파일 무시 규칙을 처리하는 인터페이스입니다
 type Ignorer interface {
 	AddPattern(pattern string) error
 	ShouldIgnore(path string) bool
+	MatchingPattern(path string) Pattern // 무시 여부를 결정한 패턴 (매칭이 없으면 nil)
 	LoadFromFile(path string) error
 }
@@ -0,0 +1,260 @@
+package ignore
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// ruleSet은 한 디렉토리(base)를 기준으로 하는 패턴 묶음입니다
+type ruleSet struct {
+	base     string
+	patterns []Pattern
+	index    *patternIndex // ruleEngine이 규칙 목록을 캐시할 때 생성
+}
+
+// lastMatch는 relPath에 마지막으로 매칭되는 패턴 번호를 반환합니다 (없으면 -1)
+// 색인은 미리 만들어져 있어야 하며, 색인을 바꾸지 않으므로 여러 고루틴에서 동시에 호출할 수 있습니다
+func (rs *ruleSet) lastMatch(relPath string, isDirectory func() bool, negatedContents bool) int {
+	matches := func(i int) bool {
+		pattern := rs.patterns[i]
+		return pattern.IsMatch(relPath) && (!pattern.IsDirectory() || isDirectory())
+	}
+
+	// 후보 목록은 번호 순이므로 뒤에서부터 처음 매칭되는 패턴만 찾으면 됨
+	best := -1
+	for _, list := range rs.index.candidates(relPath) {
+		for k := len(list) - 1; k >= 0 && list[k] > best; k-- {
+			if matches(list[k]) {
+				best = list[k]
+				break
+			}
+		}
+	}
+
+	// 부정 디렉토리 패턴은 상위 디렉토리와 매칭되어도 적용
+	if negatedContents {
+		for k := len(rs.index.negatedDirs) - 1; k >= 0 && rs.index.negatedDirs[k] > best; k-- {
+			pattern := rs.patterns[rs.index.negatedDirs[k]]
+			if matchesAncestor(pattern, relPath) {
+				best = rs.index.negatedDirs[k]
+				break
+			}
+		}
+	}
+	return best
+}
+
+// matchesAncestor는 패턴이 relPath의 상위 디렉토리 중 하나와 매칭되는지 확인합니다
+func matchesAncestor(pattern Pattern, relPath string) bool {
+	for i := strings.LastIndex(relPath, "/"); i > 0; i = strings.LastIndex(relPath[:i], "/") {
+		if pattern.IsMatch(relPath[:i]) {
+			return true
+		}
+	}
+	return false
+}
+
+// ruleEngine은 경로에 규칙을 적용하며 디렉토리별 규칙 목록과 디렉토리 무시 결과를 캐시합니다
+// 상위 디렉토리가 무시되면 그 안의 경로는 다시 포함될 수 없습니다
+// 캐시를 읽고 쓸 때만 mu를 잡으므로 여러 고루틴에서 동시에 match를 호출해도 매칭은 병렬로 진행됩니다
+type ruleEngine struct {
+	mu              sync.RWMutex
+	top             string                      // 이 디렉토리 아래의 경로에만 규칙 적용
+	negatedContents bool                        // 부정 디렉토리 패턴을 디렉토리 안의 경로에도 적용
+	setsFor         func(dir string) []*ruleSet // dir 안의 경로에 적용되는 규칙 (우선순위가 낮은 것부터)
+	sets            map[string][]*ruleSet
+	excludedDirs    map[string]Pattern // 디렉토리를 무시하게 만든 패턴 (무시되지 않으면 nil)
+}
+
+func newRuleEngine(top string, negatedContents bool, setsFor func(dir string) []*ruleSet) *ruleEngine {
+	e := &ruleEngine{
+		top:             top,
+		negatedContents: negatedContents,
+		setsFor:         setsFor,
+	}
+	e.reset()
+	return e
+}
+
+// reset은 규칙이 바뀌었을 때 캐시를 비웁니다
+func (e *ruleEngine) reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.sets = make(map[string][]*ruleSet)
+	e.excludedDirs = make(map[string]Pattern)
+}
+
+// setNegatedContents는 부정 디렉토리 패턴을 디렉토리 안의 경로에도 적용할지 설정하고 캐시를 비웁니다
+func (e *ruleEngine) setNegatedContents(enable bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.negatedContents = enable
+	e.sets = make(map[string][]*ruleSet)
+	e.excludedDirs = make(map[string]Pattern)
+}
+
+// match는 경로에 결정적으로 적용되는 패턴을 반환합니다 (매칭이 없으면 nil)
+// 상위 디렉토리가 무시되었으면 그 디렉토리를 무시한 패턴을 반환합니다
+func (e *ruleEngine) match(path string) Pattern {
+	parent := filepath.Dir(path)
+	if _, ok := relativePath(e.top, parent); ok {
+		if excluded := e.excludedBy(parent); excluded != nil {
+			return excluded
+		}
+	}
+
+	// 디렉토리 전용 패턴이 매칭될 때만 실제 파일 정보를 확인
+	var statDone, statIsDir bool
+	isDirectory := func() bool {
+		if !statDone {
+			info, err := os.Stat(path)
+			statIsDir = err == nil && info.IsDir()
+			statDone = true
+		}
+		return statIsDir
+	}
+	return e.lastMatch(parent, path, isDirectory)
+}
+
+// excludedBy는 디렉토리(상위 포함)를 무시하게 만든 패턴을 반환합니다 (무시되지 않으면 nil)
+func (e *ruleEngine) excludedBy(dir string) Pattern {
+	e.mu.RLock()
+	excluded, ok := e.excludedDirs[dir]
+	e.mu.RUnlock()
+	if ok {
+		return excluded
+	}
+
+	// 여러 고루틴이 같은 디렉토리를 동시에 계산해도 결과가 같으므로 잠그지 않고 계산
+	parent := filepath.Dir(dir)
+	if _, ok := relativePath(e.top, parent); ok {
+		excluded = e.excludedBy(parent)
+	}
+	if excluded == nil {
+		isDirectory := func() bool { return true }
+		if match := e.lastMatch(parent, dir, isDirectory); match != nil && !match.IsNegative() {
+			excluded = match
+		}
+	}
+
+	e.mu.Lock()
+	e.excludedDirs[dir] = excluded
+	e.mu.Unlock()
+	return excluded
+}
+
+// lastMatch는 parent 디렉토리에 적용되는 규칙 중 경로에 마지막으로 매칭되는 패턴을 반환합니다 (없으면 nil)
+func (e *ruleEngine) lastMatch(parent string, path string, isDirectory func() bool) Pattern {
+	sets := e.rulesFor(parent)
+
+	var last Pattern
+	for _, rs := range sets {
+		relPath, ok := relativePath(rs.base, path)
+		if !ok {
+			continue
+		}
+		if i := rs.lastMatch(relPath, isDirectory, e.negatedContents); i >= 0 {
+			last = rs.patterns[i]
+		}
+	}
+	return last
+}
+
+// rulesFor는 parent 디렉토리에 적용되는 규칙 목록을 캐시에서 찾고, 없으면 무시 파일을 읽어서 만듭니다
+// setsFor와 색인 생성은 무시 파일 캐시를 바꾸므로 쓰기 잠금을 잡은 채로 호출합니다
+func (e *ruleEngine) rulesFor(parent string) []*ruleSet {
+	e.mu.RLock()
+	sets, ok := e.sets[parent]
+	e.mu.RUnlock()
+	if ok {
+		return sets
+	}
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	if sets, ok := e.sets[parent]; ok {
+		return sets
+	}
+	sets = e.setsFor(parent)
+	for _, rs := range sets {
+		if rs.index == nil {
+			rs.index = newPatternIndex(rs.patterns)
+		}
+	}
+	e.sets[parent] = sets
+	return sets
+}
+
+// relativePath는 base 아래(base 자신 제외)에 있는 path의 슬래시 구분 상대 경로를 반환합니다
+func relativePath(base string, path string) (string, bool) {
+	// 대부분의 경로는 base로 시작하므로 filepath.Rel 없이 처리
+	if len(path) > len(base) && strings.HasPrefix(path, base) && os.IsPathSeparator(path[len(base)]) {
+		return filepath.ToSlash(path[len(base)+1:]), true
+	}
+	if path == base {
+		return "", false
+	}
+
+	relPath, err := filepath.Rel(base, path)
+	if err != nil || relPath == "." || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return "", false
+	}
+	return filepath.ToSlash(relPath), true
+}
+
+// dirRuleCache는 디렉토리마다 있는 무시 파일(fileName)을 읽어서 캐시합니다
+type dirRuleCache struct {
+	fileName string
+	dirs     map[string]*ruleSet
+}
+
+func newDirRuleCache(fileName string) *dirRuleCache {
+	return &dirRuleCache{
+		fileName: fileName,
+		dirs:     make(map[string]*ruleSet),
+	}
+}
+
+// ruleSet은 디렉토리의 무시 파일을 읽어서 캐시합니다 (파일이 없으면 nil)
+func (c *dirRuleCache) ruleSet(dir string) *ruleSet {
+	if rs, ok := c.dirs[dir]; ok {
+		return rs
+	}
+
+	var rs *ruleSet
+	if patterns, err := readPatternFile(filepath.Join(dir, c.fileName)); err == nil {
+		rs = &ruleSet{base: dir, patterns: patterns}
+	}
+	c.dirs[dir] = rs
+	return rs
+}
+
+// chain은 top부터 dir까지 내려가며 각 디렉토리의 규칙을 상위부터 반환합니다
+// includeTop이 false면 top 디렉토리 자신의 무시 파일은 제외
+func (c *dirRuleCache) chain(top string, dir string, includeTop bool) []*ruleSet {
+	relDir, err := filepath.Rel(top, dir)
+	if err != nil || relDir == ".." || strings.HasPrefix(relDir, ".."+string(filepath.Separator)) {
+		return nil
+	}
+
+	var sets []*ruleSet
+	if includeTop {
+		if rs := c.ruleSet(top); rs != nil {
+			sets = append(sets, rs)
+		}
+	}
+	if relDir == "." {
+		return sets
+	}
+
+	current := top
+	for _, part := range strings.Split(relDir, string(filepath.Separator)) {
+		current = filepath.Join(current, part)
+		if rs := c.ruleSet(current); rs != nil {
+			sets = append(sets, rs)
+		}
+	}
+	return sets
+}
@@ -0,0 +1,251 @@
+package ignore
+
+import "strings"
+
+// wildmatch 결과 (git의 wildmatch.c와 같은 의미)
+const (
+	wmNoMatch         = 0
+	wmMatch           = 1
+	wmAbortAll        = -1
+	wmAbortToStarStar = -2
+)
+
+// wildmatch는 git의 wildmatch(WM_PATHNAME)와 같은 규칙으로 text가 pattern과 매칭되는지 확인합니다
+//   - '*'와 '?'는 '/'와 매칭되지 않음
+//   - 슬래시 사이(또는 양 끝)에 있는 '**'는 0개 이상의 디렉토리와 매칭
+//   - '[...]' 범위, '[!...]'/'[^...]' 부정, '[:alpha:]' 같은 문자 클래스 지원
+//   - '\'는 다음 문자를 그대로 매칭
+func wildmatch(pattern, text string) bool {
+	return doWild(pattern, 0, text, 0) == wmMatch
+}
+
+// byteAt은 범위를 벗어나면 0을 반환 (C 문자열의 끝 표시와 같은 역할)
+func byteAt(s string, i int) byte {
+	if i < len(s) {
+		return s[i]
+	}
+	return 0
+}
+
+func isGlobSpecial(c byte) bool {
+	return c == '*' || c == '?' || c == '[' || c == '\\'
+}
+
+func doWild(pattern string, p int, text string, t int) int {
+	for ; p < len(pattern); p, t = p+1, t+1 {
+		pCh := pattern[p]
+		tCh := byteAt(text, t)
+		if tCh == 0 && pCh != '*' {
+			return wmAbortAll
+		}
+
+		switch pCh {
+		case '\\':
+			// 다음 문자를 그대로 매칭
+			p++
+			if byteAt(pattern, p) != tCh {
+				return wmNoMatch
+			}
+			continue
+
+		case '?':
+			if tCh == '/' {
+				return wmNoMatch
+			}
+			continue
+
+		case '*':
+			var matchSlash bool
+			p++
+			if byteAt(pattern, p) == '*' {
+				prev := p - 2
+				for p++; byteAt(pattern, p) == '*'; p++ {
+				}
+				next := byteAt(pattern, p)
+				if (prev < 0 || pattern[prev] == '/') &&
+					(next == 0 || next == '/' || (next == '\\' && byteAt(pattern, p+1) == '/')) {
+					// "foo/**/bar"가 "foo/bar"와도 매칭되도록 '**/'를 빈 문자열로 먼저 시도
+					if next == '/' && doWild(pattern, p+1, text, t) == wmMatch {
+						return wmMatch
+					}
+					matchSlash = true
+				}
+			}
+
+			if p >= len(pattern) {
+				// 끝의 "**"는 모든 것과 매칭, 끝의 "*"는 남은 부분에 '/'가 없어야 매칭
+				if !matchSlash && strings.IndexByte(text[t:], '/') >= 0 {
+					return wmNoMatch
+				}
+				return wmMatch
+			}
+			if !matchSlash && pattern[p] == '/' {
+				// '*' 하나 뒤에 '/'가 오면 다음 디렉토리까지 매칭
+				slash := strings.IndexByte(text[t:], '/')
+				if slash < 0 {
+					return wmNoMatch
+				}
+				t += slash
+				continue
+			}
+
+			for tCh != 0 {
+				// '*' 뒤가 일반 문자면 그 문자가 나올 때까지 건너뜀
+				if !isGlobSpecial(pattern[p]) {
+					pCh = pattern[p]
+					for tCh = byteAt(text, t); tCh != 0 && (matchSlash || tCh != '/'); tCh = byteAt(text, t) {
+						if tCh == pCh {
+							break
+						}
+						t++
+					}
+					if tCh != pCh {
+						return wmNoMatch
+					}
+				}
+				if matched := doWild(pattern, p, text, t); matched != wmNoMatch {
+					if !matchSlash || matched != wmAbortToStarStar {
+						return matched
+					}
+				} else if !matchSlash && tCh == '/' {
+					return wmAbortToStarStar
+				}
+				t++
+				tCh = byteAt(text, t)
+			}
+			return wmAbortAll
+
+		case '[':
+			p++
+			pCh = byteAt(pattern, p)
+			if pCh == '^' {
+				pCh = '!'
+			}
+			negated := pCh == '!'
+			if negated {
+				p++
+				pCh = byteAt(pattern, p)
+			}
+
+			var prevCh byte
+			matched := false
+			for {
+				if pCh == 0 {
+					return wmAbortAll
+				}
+				if pCh == '\\' {
+					p++
+					pCh = byteAt(pattern, p)
+					if pCh == 0 {
+						return wmAbortAll
+					}
+					if tCh == pCh {
+						matched = true
+					}
+				} else if pCh == '-' && prevCh != 0 && byteAt(pattern, p+1) != 0 && byteAt(pattern, p+1) != ']' {
+					p++
+					pCh = pattern[p]
+					if pCh == '\\' {
+						p++
+						pCh = byteAt(pattern, p)
+						if pCh == 0 {
+							return wmAbortAll
+						}
+					}
+					if tCh <= pCh && tCh >= prevCh {
+						matched = true
+					}
+					pCh = 0 // 범위 다음에는 prevCh를 비움
+				} else if pCh == '[' && byteAt(pattern, p+1) == ':' {
+					start := p + 2
+					p = start
+					for byteAt(pattern, p) != 0 && pattern[p] != ']' {
+						p++
+					}
+					if byteAt(pattern, p) == 0 {
+						return wmAbortAll
+					}
+					length := p - start - 1
+					if length < 0 || pattern[p-1] != ':' {
+						// ":]"가 없으면 일반 문자 집합처럼 처리
+						p = start - 2
+						pCh = '['
+						if tCh == pCh {
+							matched = true
+						}
+					} else {
+						isMember, ok := matchCharClass(pattern[start:start+length], tCh)
+						if !ok {
+							return wmAbortAll
+						}
+						if isMember {
+							matched = true
+						}
+						pCh = 0
+					}
+				} else if tCh == pCh {
+					matched = true
+				}
+
+				prevCh = pCh
+				p++
+				pCh = byteAt(pattern, p)
+				if pCh == ']' {
+					break
+				}
+			}
+			if matched == negated || tCh == '/' {
+				return wmNoMatch
+			}
+			continue
+
+		default:
+			if tCh != pCh {
+				return wmNoMatch
+			}
+			continue
+		}
+	}
+
+	if t < len(text) {
+		return wmNoMatch
+	}
+	return wmMatch
+}
+
+// matchCharClass는 [:name:] 문자 클래스에 c가 속하는지 확인합니다 (알 수 없는 클래스면 ok가 false)
+func matchCharClass(name string, c byte) (isMember bool, ok bool) {
+	isUpper := c >= 'A' && c <= 'Z'
+	isLower := c >= 'a' && c <= 'z'
+	isDigit := c >= '0' && c <= '9'
+	isAlpha := isUpper || isLower
+	isPrint := c >= 0x20 && c < 0x7f
+
+	switch name {
+	case "alnum":
+		return isAlpha || isDigit, true
+	case "alpha":
+		return isAlpha, true
+	case "blank":
+		return c == ' ' || c == '\t', true
+	case "cntrl":
+		return c < 0x20 || c == 0x7f, true
+	case "digit":
+		return isDigit, true
+	case "graph":
+		return isPrint && c != ' ', true
+	case "lower":
+		return isLower, true
+	case "print":
+		return isPrint, true
+	case "punct":
+		return isPrint && c != ' ' && !isAlpha && !isDigit, true
+	case "space":
+		return c == ' ' || (c >= '\t' && c <= '\r'), true
+	case "upper":
+		return isUpper, true
+	case "xdigit":
+		return isDigit || (c >= 'a' && c <= 'f') || (c >= 'A' && c <= 'F'), true
+	}
+	return false, false
+}
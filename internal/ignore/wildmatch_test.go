@@ -0,0 +1,187 @@
+package ignore
+
+import "testing"
+
+// TestWildmatch는 git의 t3070-wildmatch.sh에서 가져온 WM_PATHNAME 기준 매칭 테스트
+func TestWildmatch(t *testing.T) {
+	tests := []struct {
+		text    string
+		pattern string
+		want    bool
+	}{
+		// 기본 기능
+		{`foo`, `foo`, true},
+		{`foo`, `bar`, false},
+		{``, ``, true},
+		{`foo`, `???`, true},
+		{`foo`, `??`, false},
+		{`foo`, `*`, true},
+		{`foo`, `f*`, true},
+		{`foo`, `*f`, false},
+		{`foo`, `*foo*`, true},
+		{`foobar`, `*ob*a*r*`, true},
+		{`aaaaaaabababab`, `*ab`, true},
+		{`foo*`, `foo\*`, true},
+		{`foobar`, `foo\*bar`, false},
+		{`f\oo`, `f\\oo`, true},
+		{`ball`, `*[al]?`, true},
+		{`ten`, `[ten]`, false},
+		{`ten`, `**[!te]`, true},
+		{`ten`, `**[!ten]`, false},
+		{`ten`, `t[a-g]n`, true},
+		{`ten`, `t[!a-g]n`, false},
+		{`ton`, `t[!a-g]n`, true},
+		{`ton`, `t[^a-g]n`, true},
+		{`a]b`, `a[]]b`, true},
+		{`a-b`, `a[]-]b`, true},
+		{`a]b`, `a[]-]b`, true},
+		{`aab`, `a[]-]b`, false},
+		{`aab`, `a[]a-]b`, true},
+		{`]`, `]`, true},
+
+		// 슬래시 관련 기능
+		{`foo/baz/bar`, `foo*bar`, false},
+		{`foo/baz/bar`, `foo**bar`, false},
+		{`foobazbar`, `foo**bar`, true},
+		{`foo/baz/bar`, `foo/**/bar`, true},
+		{`foo/baz/bar`, `foo/**/**/bar`, true},
+		{`foo/b/a/z/bar`, `foo/**/bar`, true},
+		{`foo/b/a/z/bar`, `foo/**/**/bar`, true},
+		{`foo/bar`, `foo/**/bar`, true},
+		{`foo/bar`, `foo/**/**/bar`, true},
+		{`foo/bar`, `foo?bar`, false},
+		{`foo/bar`, `foo[/]bar`, false},
+		{`foo/bar`, `foo[^a-z]bar`, false},
+		{`foo/bar`, `f[^eiu][^eiu][^eiu][^eiu][^eiu]r`, false},
+		{`foo-bar`, `f[^eiu][^eiu][^eiu][^eiu][^eiu]r`, true},
+		{`foo`, `**/foo`, true},
+		{`XXX/foo`, `**/foo`, true},
+		{`bar/baz/foo`, `**/foo`, true},
+		{`bar/baz/foo`, `*/foo`, false},
+		{`foo/bar/baz`, `**/bar*`, false},
+		{`deep/foo/bar/baz`, `**/bar/*`, true},
+		{`deep/foo/bar/baz/`, `**/bar/*`, false},
+		{`deep/foo/bar/baz/`, `**/bar/**`, true},
+		{`deep/foo/bar`, `**/bar/*`, false},
+		{`deep/foo/bar/`, `**/bar/**`, true},
+		{`foo/bar/baz`, `**/bar**`, false},
+		{`foo/bar/baz/x`, `*/bar/**`, true},
+		{`deep/foo/bar/baz/x`, `*/bar/**`, false},
+		{`deep/foo/bar/baz/x`, `**/bar/*/*`, true},
+
+		// 기타
+		{`acrt`, `a[c-c]st`, false},
+		{`acrt`, `a[c-c]rt`, true},
+		{`]`, `[!]-]`, false},
+		{`a`, `[!]-]`, true},
+		{``, `\`, false},
+		{`foo`, `foo`, true},
+		{`@foo`, `@foo`, true},
+		{`foo`, `@foo`, false},
+		{`[ab]`, `\[ab]`, true},
+		{`[ab]`, `[[]ab]`, true},
+		{`[ab]`, `[[:]ab]`, true},
+		{`[ab]`, `[[::]ab]`, false},
+		{`[ab]`, `[[:digit]ab]`, true},
+		{`[ab]`, `[\[:]ab]`, true},
+		{`?a?b`, `\??\?b`, true},
+		{`abc`, `\a\b\c`, true},
+		{`foo`, ``, false},
+		{`foo/bar/baz/to`, `**/t[o]`, true},
+
+		// 문자 클래스
+		{`a1B`, `[[:alpha:]][[:digit:]][[:upper:]]`, true},
+		{`a`, `[[:digit:][:upper:][:space:]]`, false},
+		{`A`, `[[:digit:][:upper:][:space:]]`, true},
+		{`1`, `[[:digit:][:upper:][:space:]]`, true},
+		{`1`, `[[:digit:][:upper:][:spaci:]]`, false},
+		{` `, `[[:digit:][:upper:][:space:]]`, true},
+		{`.`, `[[:digit:][:upper:][:space:]]`, false},
+		{`.`, `[[:digit:][:punct:][:space:]]`, true},
+		{`5`, `[[:xdigit:]]`, true},
+		{`f`, `[[:xdigit:]]`, true},
+		{`D`, `[[:xdigit:]]`, true},
+		{`_`, `[[:alnum:][:alpha:][:blank:][:cntrl:][:digit:][:graph:][:lower:][:print:][:punct:][:space:][:upper:][:xdigit:]]`, true},
+		{`.`, `[^[:alnum:][:alpha:][:blank:][:cntrl:][:digit:][:lower:][:space:][:upper:][:xdigit:]]`, true},
+		{`5`, `[a-c[:digit:]x-z]`, true},
+		{`b`, `[a-c[:digit:]x-z]`, true},
+		{`y`, `[a-c[:digit:]x-z]`, true},
+		{`q`, `[a-c[:digit:]x-z]`, false},
+
+		// 잘못된 형식을 포함한 추가 테스트
+		{`]`, `[\\-^]`, true},
+		{`[`, `[\\-^]`, false},
+		{`-`, `[\-_]`, true},
+		{`]`, `[\]]`, true},
+		{`\]`, `[\]]`, false},
+		{`\`, `[\]]`, false},
+		{`ab`, `a[]b`, false},
+		{`a[]b`, `a[]b`, false},
+		{`ab[`, `ab[`, false},
+		{`ab`, `[!`, false},
+		{`ab`, `[-`, false},
+		{`-`, `[-]`, true},
+		{`-`, `[a-`, false},
+		{`-`, `[!a-`, false},
+		{`-`, `[--A]`, true},
+		{`5`, `[--A]`, true},
+		{` `, `[ --]`, true},
+		{`$`, `[ --]`, true},
+		{`-`, `[ --]`, true},
+		{`0`, `[ --]`, false},
+		{`-`, `[---]`, true},
+		{`-`, `[------]`, true},
+		{`j`, `[a-e-n]`, false},
+		{`-`, `[a-e-n]`, true},
+		{`a`, `[!------]`, true},
+		{`[`, `[]-a]`, false},
+		{`^`, `[]-a]`, true},
+		{`^`, `[!]-a]`, false},
+		{`[`, `[!]-a]`, true},
+		{`^`, `[a^bc]`, true},
+		{`-b]`, `[a-]b]`, true},
+		{`\`, `[\]`, false},
+		{`\`, `[\\]`, true},
+		{`\`, `[!\\]`, false},
+		{`G`, `[A-\\]`, true},
+		{`aaabbb`, `b*a`, false},
+		{`aabcaa`, `*ba*`, false},
+		{`,`, `[,]`, true},
+		{`,`, `[\\,]`, true},
+		{`\`, `[\\,]`, true},
+		{`-`, `[,-.]`, true},
+		{`+`, `[,-.]`, false},
+		{`-.]`, `[,-.]`, false},
+		{`2`, `[\1-\3]`, true},
+		{`3`, `[\1-\3]`, true},
+		{`4`, `[\1-\3]`, false},
+		{`\`, `[[-\]]`, true},
+		{`[`, `[[-\]]`, true},
+		{`]`, `[[-\]]`, true},
+		{`-`, `[[-\]]`, false},
+
+		// 재귀
+		{`-adobe-courier-bold-o-normal--12-120-75-75-m-70-iso8859-1`, `-*-*-*-*-*-*-12-*-*-*-m-*-*-*`, true},
+		{`-adobe-courier-bold-o-normal--12-120-75-75-X-70-iso8859-1`, `-*-*-*-*-*-*-12-*-*-*-m-*-*-*`, false},
+		{`-adobe-courier-bold-o-normal--12-120-75-75-/-70-iso8859-1`, `-*-*-*-*-*-*-12-*-*-*-m-*-*-*`, false},
+		{`XXX/adobe/courier/bold/o/normal//12/120/75/75/m/70/iso8859/1`, `XXX/*/*/*/*/*/*/12/*/*/*/m/*/*/*`, true},
+		{`XXX/adobe/courier/bold/o/normal//12/120/75/75/X/70/iso8859/1`, `XXX/*/*/*/*/*/*/12/*/*/*/m/*/*/*`, false},
+		{`abcd/abcdefg/abcdefghijk/abcdefghijklmnop.txt`, `**/*a*b*g*n*t`, true},
+		{`abcd/abcdefg/abcdefghijk/abcdefghijklmnop.txtz`, `**/*a*b*g*n*t`, false},
+		{`foo`, `*/*/*`, false},
+		{`foo/bar`, `*/*/*`, false},
+		{`foo/bba/arr`, `*/*/*`, true},
+		{`foo/bb/aa/rr`, `*/*/*`, false},
+		{`foo/bb/aa/rr`, `**/**/**`, true},
+		{`abcXdefXghi`, `*X*i`, true},
+		{`ab/cXd/efXg/hi`, `*X*i`, false},
+		{`ab/cXd/efXg/hi`, `*/*X*/*/*i`, true},
+		{`ab/cXd/efXg/hi`, `**/*X*/**/*i`, true},
+	}
+
+	for _, tt := range tests {
+		if got := wildmatch(tt.pattern, tt.text); got != tt.want {
+			t.Errorf("wildmatch(%q, %q) = %v, want %v", tt.pattern, tt.text, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"unicode/utf8"
+)
+
+// sniffLen은 바이너리 여부를 판단할 때 읽는 앞부분 크기 (git과 같은 값)
+const sniffLen = 8000
+
+// binaryThreshold는 바이너리로 판단하는 의심 바이트(잘못된 UTF-8, 제어 문자) 비율
+const binaryThreshold = 0.3
+
+// binaryMagics는 바이너리 파일 형식의 시작 바이트
+var binaryMagics = [][]byte{
+	[]byte("\x89PNG\r\n\x1a\n"),   // PNG
+	[]byte("\xff\xd8\xff"),        // JPEG
+	[]byte("GIF87a"),              // GIF
+	[]byte("GIF89a"),              // GIF
+	[]byte("%PDF-"),               // PDF
+	[]byte("PK\x03\x04"),          // ZIP, JAR, DOCX
+	[]byte("\x1f\x8b"),            // gzip
+	[]byte("\xfd7zXZ\x00"),        // xz
+	[]byte("7z\xbc\xaf\x27\x1c"),  // 7z
+	[]byte("\x28\xb5\x2f\xfd"),    // zstd
+	[]byte("\x7fELF"),             // ELF
+	[]byte("\xcf\xfa\xed\xfe"),    // Mach-O 64비트
+	[]byte("\xce\xfa\xed\xfe"),    // Mach-O 32비트
+	[]byte("\xca\xfe\xba\xbe"),    // Java class, Mach-O 유니버설
+	[]byte("\x00asm"),             // WebAssembly
+	[]byte("SQLite format 3\x00"), // SQLite
+}
+
+// textBOMs는 텍스트 파일임을 나타내는 BOM (UTF-16은 NUL 바이트가 있어도 텍스트)
+var textBOMs = [][]byte{
+	[]byte("\xef\xbb\xbf"), // UTF-8
+	[]byte("\xff\xfe"),     // UTF-16 LE
+	[]byte("\xfe\xff"),     // UTF-16 BE
+}
+
+// IsBinary는 내용의 앞부분으로 바이너리 여부를 판단
+//   - 알려진 바이너리 형식의 시작 바이트가 있으면 바이너리
+//   - 텍스트 BOM으로 시작하면 텍스트
+//   - NUL 바이트 없이 EUC-KR/CP949 바이트 구조와 맞으면 텍스트
+//   - NUL 바이트가 있거나 잘못된 UTF-8과 제어 문자의 비율이 높으면 바이너리
+func IsBinary(data []byte) bool {
+	if len(data) > sniffLen {
+		data = data[:sniffLen]
+	}
+
+	for _, magic := range binaryMagics {
+		if bytes.HasPrefix(data, magic) {
+			return true
+		}
+	}
+	for _, bom := range textBOMs {
+		if bytes.HasPrefix(data, bom) {
+			return false
+		}
+	}
+	if bytes.IndexByte(data, 0) >= 0 {
+		return true
+	}
+	if !utf8.Valid(data) && isEUCKR(data, true) {
+		return false // EUC-KR/CP949 텍스트
+	}
+
+	suspicious, total := 0, 0
+	for len(data) > 0 {
+		r, size := utf8.DecodeRune(data)
+		if r == utf8.RuneError && size == 1 {
+			if !utf8.FullRune(data) {
+				break // 앞부분만 읽어서 잘린 마지막 문자
+			}
+			suspicious++
+		} else if r < 0x20 && r != '\t' && r != '\n' && r != '\r' && r != '\f' && r != '\b' && r != 0x1b {
+			suspicious++
+		}
+		total++
+		data = data[size:]
+	}
+	return total > 0 && float64(suspicious)/float64(total) > binaryThreshold
+}
+
+// IsBinary 구현 (파일 앞부분만 읽어서 판단)
+func (fp *fileParser) IsBinary(path string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return false, NewParseError(path, err)
+	}
+	defer file.Close()
+
+	head := make([]byte, sniffLen)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		return false, NewParseError(path, err)
+	}
+	return IsBinary(head[:n]), nil
+}
@@ -1,117 +1,242 @@
 package parser
 
 import (
-	"os"
 	"path/filepath"
+	"strings"
 
+	"github.com/kihyun1998/codemd/internal/file"
 	"github.com/kihyun1998/codemd/internal/ignore"
-	"github.com/kihyun1998/codemd/pkg/utils"
 )
 
 // DirectoryParser 구현체
 type directoryParser struct {
-	excludeDirs   []string
-	includeHidden bool // 숨김 파일 포함 여부 추가
-	ignorer       ignore.Ignorer
-	rootDir       string
+	excludes      []ignore.Pattern // -exclude 항목 (gitignore 문법)
+	hidden        HiddenPolicy     // 숨김 파일 처리 방식
+	useCodeIgnore bool             // 루트와 하위 디렉토리의 .codeignore 사용 여부
+	useGitIgnore  bool             // .gitignore 규칙 사용 여부
+	legacyNegate  bool             // .codeignore의 부정 디렉토리 패턴이 디렉토리 안의 파일도 다시 포함
+	includes      []ignore.Pattern // -include로 지정한 파일 선택 패턴
+	outputPath    string           // 출력 파일 경로 (절대 경로, 분할 파트와 인덱스 파일도 제외)
+	jobs          int              // 디렉토리를 동시에 읽는 작업자 수
+	keepGoing     bool             // 읽을 수 없는 디렉토리를 건너뛰고 계속할지 여부
+	skipped       []*ParseError    // 마지막 Parse에서 건너뛴 디렉토리
+	symlinks      SymlinkMode      // 심볼릭 링크 처리 방식
+	links         []Symlink        // 마지막 Parse에서 내용 대신 링크로 기록한 심볼릭 링크
 }
 
+// excludeDirs는 제외할 이름, 루트 기준 경로, glob 패턴 (gitignore 문법, 예: vendor, internal/legacy, *.min.js)
+// includeHidden은 "."으로 시작하는 파일 포함 여부 ("_"로 시작하는 파일은 기본적으로 포함)
 func NewDirectoryParser(excludeDirs []string, includeHidden bool, useCodeIgnore bool) DirectoryParser {
-	var ignorer ignore.Ignorer
+	return &directoryParser{
+		excludes:      parseExcludes(excludeDirs),
+		hidden:        HiddenPolicy{IncludeDot: includeHidden},
+		useCodeIgnore: useCodeIgnore,
+		jobs:          1,
+		symlinks:      SymlinkFollowFiles,
+	}
+}
 
-	rootDir, err := os.Getwd()
-	if err != nil {
-		rootDir = "."
+// 동시에 디렉토리를 읽는 작업자 수 설정 (1 이하면 순차 탐색)
+func (d *directoryParser) SetJobs(jobs int) {
+	d.jobs = jobs
+}
+
+// 숨김 파일 처리 방식 설정
+func (d *directoryParser) SetHiddenPolicy(policy HiddenPolicy) {
+	d.hidden = policy
+}
+
+// 출력 파일 경로 설정 (출력 파일과 분할 파트, 인덱스 파일은 항상 제외)
+func (d *directoryParser) SetOutputPath(path string) {
+	if absPath, err := filepath.Abs(path); err == nil {
+		path = absPath
 	}
+	d.outputPath = path
+}
+
+// 읽을 수 없는 디렉토리를 에러 대신 건너뛸지 설정 (루트 디렉토리 에러는 항상 실패)
+func (d *directoryParser) SetKeepGoing(keep bool) {
+	d.keepGoing = keep
+}
 
-	if useCodeIgnore {
-		codeIgnorePath := filepath.Join(rootDir, ".codeignore")
-		if ci, err := ignore.NewCodeIgnore(codeIgnorePath); err == nil {
-			ignorer = ci
+// 마지막 Parse에서 읽을 수 없어서 건너뛴 디렉토리들
+func (d *directoryParser) Skipped() []*ParseError {
+	return d.skipped
+}
+
+// 심볼릭 링크 처리 방식 설정 (기본값은 follow-files)
+func (d *directoryParser) SetSymlinkMode(mode SymlinkMode) {
+	d.symlinks = mode
+}
+
+// 마지막 Parse에서 내용 대신 링크로 기록한 심볼릭 링크들 (탐색 순서)
+func (d *directoryParser) Links() []Symlink {
+	return d.links
+}
+
+// .gitignore 사용 여부 설정
+func (d *directoryParser) SetGitIgnore(use bool) {
+	d.useGitIgnore = use
+}
+
+// .codeignore의 부정 디렉토리 패턴(!dir/)이 다른 규칙으로 무시된 디렉토리 안의 파일도 다시 포함할지 설정 (이전 동작)
+func (d *directoryParser) SetCodeIgnoreLegacy(legacy bool) {
+	d.legacyNegate = legacy
+}
+
+// 루트 디렉토리에 적용할 무시 규칙 로드 (.gitignore, .codeignore 순서)
+func (d *directoryParser) loadIgnorers(root string) []ignore.Ignorer {
+	var ignorers []ignore.Ignorer
+	if d.useGitIgnore {
+		if gi, err := ignore.NewGitIgnore(root); err == nil {
+			ignorers = append(ignorers, gi)
 		}
 	}
-
-	return &directoryParser{
-		excludeDirs:   excludeDirs,
-		includeHidden: includeHidden,
-		ignorer:       ignorer,
-		rootDir:       rootDir,
+	if d.useCodeIgnore {
+		if ci, err := ignore.NewCodeIgnoreFromDir(root); err == nil {
+			if legacy, ok := ci.(*ignore.CodeIgnore); ok && d.legacyNegate {
+				legacy.SetLegacyNegation(true)
+			}
+			ignorers = append(ignorers, ci)
+		}
 	}
+	return ignorers
 }
 
-// 모든 파일 가져오기
-func (d *directoryParser) Parse(root string) ([]string, error) {
-	var files []string
+// check는 Parse가 경로를 거르는 단계(output, ignore, hidden, exclude)를 순서대로 적용
+// 파일을 열어야 하는 생성 표시 확인은 다른 단계를 모두 통과한 파일에만 마지막으로 적용
+func (d *directoryParser) check(root string, ignorers []ignore.Ignorer, path string, isDir bool) Decision {
+	decision := Decision{Path: path, Target: path, Included: true}
 
-	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return NewParseError(path, err)
+	// 출력 파일 체크 (설정된 출력 경로와 분할 파트, 인덱스 파일)
+	if !isDir {
+		if rule, ok := d.isOutputName(path); ok {
+			return Decision{Path: path, Target: path, Stage: StageOutput, Rule: rule}
 		}
+	}
 
-		// .codeignore 규칙 체크
-		if d.ignorer != nil {
-			if d.ignorer.ShouldIgnore(path) {
-				if info.IsDir() {
-					return filepath.SkipDir
-				}
-				return nil
+	// .gitignore, .codeignore 규칙 체크 (부정 패턴으로 포함된 경우도 기록)
+	for _, ignorer := range ignorers {
+		if pattern := ignorer.MatchingPattern(path); pattern != nil {
+			decision.Stage = StageIgnore
+			decision.Rule = pattern.String()
+			decision.Source = pattern.Source()
+			decision.Line = pattern.Line()
+			if !pattern.IsNegative() {
+				decision.Included = false
+				return decision
 			}
 		}
+	}
 
-		// 숨김 파일 체크
-		if !d.includeHidden && utils.IsHidden(info.Name()) {
-			if info.IsDir() {
-				return filepath.SkipDir
-			}
-			return nil
+	// 숨김 파일 체크 (허용 목록에 있으면 포함)
+	name := filepath.Base(path)
+	relPath, err := filepath.Rel(root, path)
+	if err != nil {
+		relPath = name
+	}
+	relPath = filepath.ToSlash(relPath)
+	if d.hidden.isHidden(name) {
+		entry, ok := d.hidden.allowed(relPath, isDir)
+		if !ok {
+			return Decision{Path: path, Target: path, Stage: StageHidden, Rule: name}
 		}
+		decision = Decision{Path: path, Target: path, Included: true, Stage: StageHidden, Rule: entry}
+	}
 
-		if info.IsDir() {
-			if d.isExcluded(info.Name()) {
-				return filepath.SkipDir
-			}
-			return nil
-		}
+	// -exclude 체크 (부정 패턴이나 숨김 허용 목록으로 포함된 경로도 제외)
+	if rule, ok := d.isExcluded(relPath, isDir); ok {
+		return Decision{Path: path, Target: path, Stage: StageExclude, Rule: rule}
+	}
+
+	// 이전 실행에서 다른 -out 설정으로 생성된 파일 체크
+	if !isDir && d.mayBeOutput(path) && file.HasGeneratedMarker(path) {
+		return Decision{Path: path, Target: path, Stage: StageOutput, Rule: strings.TrimSpace(file.GeneratedMarker)}
+	}
 
-		files = append(files, path)
-		return nil
-	})
+	return decision
+}
 
+// 모든 파일 가져오기 (jobs개의 작업자가 디렉토리를 나눠 읽고, 결과는 탐색 순서로 정렬)
+func (d *directoryParser) Parse(root string) ([]string, error) {
+	w := d.newWalker(root, d.loadIgnorers(root))
+	err := w.run()
+	d.skipped, d.links = w.skipped, w.links
 	if err != nil {
 		return nil, err
 	}
+	return w.files, nil
+}
 
-	return files, nil
+// newWalker는 Parse와 같은 설정과 필터로 root를 탐색하는 walker를 만듦
+func (d *directoryParser) newWalker(root string, ignorers []ignore.Ignorer) *walker {
+	return &walker{
+		root:      root,
+		jobs:      d.jobs,
+		keepGoing: d.keepGoing,
+		symlinks:  d.symlinks,
+		filter: func(path string, isDir bool) bool {
+			return d.check(root, ignorers, path, isDir).Included
+		},
+	}
 }
 
 // 특정 타입의 파일만 필터링 (마크다운 생성용)
 func (d *directoryParser) GetFilesByTypes(allFiles []string, types []string) []string {
-	if len(types) == 0 || (len(types) == 1 && types[0] == "") {
+	if !hasTypes(types) {
 		return allFiles
 	}
 
 	var filtered []string
 	for _, file := range allFiles {
-		ext := filepath.Ext(file)
-		if ext != "" {
-			ext = ext[1:] // 점(.) 제거
-			for _, t := range types {
-				if ext == t {
-					filtered = append(filtered, file)
-					break
-				}
-			}
+		if _, ok := matchType(file, types); ok {
+			filtered = append(filtered, file)
 		}
 	}
 	return filtered
 }
 
-// 제외 디렉토리 확인
-func (d *directoryParser) isExcluded(dir string) bool {
-	for _, excludeDir := range d.excludeDirs {
-		if dir == excludeDir {
-			return true
+// isOutputName은 파일이 설정된 출력 파일이나 그 분할 파트, 인덱스 파일이면 출력 파일 이름을 반환
+func (d *directoryParser) isOutputName(path string) (string, bool) {
+	if d.outputPath != "" {
+		if absPath, err := filepath.Abs(path); err == nil && file.IsOutputName(d.outputPath, absPath) {
+			return filepath.Base(d.outputPath), true
+		}
+	}
+	return "", false
+}
+
+// mayBeOutput은 생성 표시를 확인할 파일인지 확장자로 판단 (.md 또는 출력 파일과 같은 확장자)
+func (d *directoryParser) mayBeOutput(path string) bool {
+	ext := strings.ToLower(filepath.Ext(path))
+	return ext == ".md" || (d.outputPath != "" && ext == strings.ToLower(filepath.Ext(d.outputPath)))
+}
+
+// parseExcludes는 -exclude 항목들을 gitignore 문법의 패턴으로 해석
+//   - 슬래시가 없는 항목은 어느 깊이에서든 이름과 매칭 (예: vendor, *.min.js)
+//   - 슬래시가 있는 항목은 루트 기준 경로와 매칭 (예: internal/legacy, docs/**/*.png)
+//   - 끝이 "/"인 항목은 디렉토리만 매칭
+func parseExcludes(entries []string) []ignore.Pattern {
+	var excludes []ignore.Pattern
+	for _, entry := range entries {
+		entry = strings.TrimPrefix(filepath.ToSlash(strings.TrimSpace(entry)), "./")
+		if entry == "" || strings.HasPrefix(entry, "!") {
+			continue // 부정 패턴은 -exclude에서 의미가 없음
+		}
+		excludes = append(excludes, ignore.ParsePattern(entry))
+	}
+	return excludes
+}
+
+// isExcluded는 루트 기준 경로가 -exclude 항목과 매칭되면 그 항목을 반환
+func (d *directoryParser) isExcluded(relPath string, isDir bool) (string, bool) {
+	for _, pattern := range d.excludes {
+		if pattern.IsDirectory() && !isDir {
+			continue
+		}
+		if pattern.IsMatch(relPath) {
+			return pattern.String(), true
 		}
 	}
-	return false
+	return "", false
 }
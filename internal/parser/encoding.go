@@ -0,0 +1,83 @@
+package parser
+
+import (
+	"bytes"
+	"unicode/utf8"
+
+	"golang.org/x/text/encoding"
+	"golang.org/x/text/encoding/charmap"
+	"golang.org/x/text/encoding/korean"
+	"golang.org/x/text/encoding/unicode"
+)
+
+// 감지할 수 있는 원본 인코딩 이름
+const (
+	EncodingUTF8        = "UTF-8"
+	EncodingUTF8BOM     = "UTF-8-BOM"
+	EncodingUTF16LE     = "UTF-16LE"
+	EncodingUTF16BE     = "UTF-16BE"
+	EncodingEUCKR       = "EUC-KR" // CP949 확장 문자 포함
+	EncodingWindows1252 = "Windows-1252"
+)
+
+// TextContent는 UTF-8로 변환한 파일 내용과 원본 인코딩
+type TextContent struct {
+	Content    string
+	Raw        []byte // 디스크에 있는 그대로의 바이트 (인코딩, 줄바꿈 변환과 크기 제한 전)
+	Encoding   string
+	Truncation *Truncation // 크기 제한으로 잘렸으면 잘린 정보
+}
+
+// DecodeText는 인코딩을 감지해서 내용을 UTF-8로 변환
+//   - BOM이 있으면 BOM의 인코딩 (UTF-8, UTF-16LE, UTF-16BE)
+//   - 올바른 UTF-8이면 그대로 사용
+//   - EUC-KR/CP949 바이트 구조와 맞으면 EUC-KR
+//   - 그 밖에는 Windows-1252 (모든 바이트를 변환할 수 있음)
+func DecodeText(data []byte) (string, string) {
+	switch {
+	case bytes.HasPrefix(data, []byte("\xef\xbb\xbf")):
+		return string(data[3:]), EncodingUTF8BOM
+	case bytes.HasPrefix(data, []byte("\xff\xfe")):
+		return decodeWith(unicode.UTF16(unicode.LittleEndian, unicode.ExpectBOM), data), EncodingUTF16LE
+	case bytes.HasPrefix(data, []byte("\xfe\xff")):
+		return decodeWith(unicode.UTF16(unicode.BigEndian, unicode.ExpectBOM), data), EncodingUTF16BE
+	case utf8.Valid(data):
+		return string(data), EncodingUTF8
+	case isEUCKR(data, false):
+		return decodeWith(korean.EUCKR, data), EncodingEUCKR
+	}
+	return decodeWith(charmap.Windows1252, data), EncodingWindows1252
+}
+
+// decodeWith는 지정한 인코딩으로 내용을 UTF-8로 변환 (변환할 수 없는 문자는 U+FFFD)
+func decodeWith(enc encoding.Encoding, data []byte) string {
+	decoded, err := enc.NewDecoder().Bytes(data)
+	if err != nil {
+		return string(data)
+	}
+	return string(decoded)
+}
+
+// isEUCKR는 ASCII가 아닌 바이트가 모두 CP949 2바이트 문자 구조인지 확인
+// allowTruncated가 true면 앞부분만 읽어서 잘린 마지막 선행 바이트를 허용
+func isEUCKR(data []byte, allowTruncated bool) bool {
+	for i := 0; i < len(data); {
+		lead := data[i]
+		if lead < 0x80 {
+			i++
+			continue
+		}
+		if lead == 0x80 || lead == 0xff {
+			return false
+		}
+		if i+1 == len(data) {
+			return allowTruncated
+		}
+		trail := data[i+1]
+		if !(trail >= 0x41 && trail <= 0x5a || trail >= 0x61 && trail <= 0x7a || trail >= 0x81 && trail <= 0xfe) {
+			return false
+		}
+		i += 2
+	}
+	return true
+}
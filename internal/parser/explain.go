@@ -0,0 +1,157 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/kihyun1998/codemd/internal/ignore"
+)
+
+// FilterStage는 경로를 거르는 단계
+type FilterStage string
+
+const (
+	StageOutput  FilterStage = "output"  // codemd가 생성한 출력 파일
+	StageIgnore  FilterStage = "ignore"  // .gitignore, .codeignore 규칙
+	StageHidden  FilterStage = "hidden"  // 숨김 파일/디렉토리 (허용 목록 포함)
+	StageExclude FilterStage = "exclude" // -exclude 디렉토리
+	StageSymlink FilterStage = "symlink" // -symlinks 처리 방식
+	StageType    FilterStage = "type"    // -type 확장자나 파일 이름
+	StageInclude FilterStage = "include" // -include 패턴
+)
+
+// Decision은 경로가 포함되거나 제외된 이유
+type Decision struct {
+	Path     string      // 확인한 경로
+	Target   string      // 규칙과 매칭된 경로 (상위 디렉토리일 수 있음)
+	Included bool        // 출력에 포함되는지 여부
+	Stage    FilterStage // 결정한 단계 (결정한 규칙이 없으면 빈 문자열)
+	Rule     string      // 결정한 규칙 (출력 경로나 생성 표시, 패턴, 숨김 이름이나 허용 항목, 제외 디렉토리, 링크 처리 방식, 타입, 포함 패턴)
+	Source   string      // 규칙이 정의된 파일 (ignore 단계)
+	Line     int         // 규칙이 정의된 줄 번호 (ignore 단계)
+}
+
+// String은 "상태<TAB>단계<TAB>규칙<TAB>경로" 형식으로 변환 (git check-ignore -v와 비슷한 형식)
+func (d Decision) String() string {
+	status := "included"
+	if !d.Included {
+		status = "excluded"
+	}
+
+	stage, rule := string(d.Stage), d.Rule
+	if d.Stage == "" {
+		stage, rule = "-", "-"
+	}
+	if d.Source != "" {
+		rule = fmt.Sprintf("%s:%d:%s", d.Source, d.Line, d.Rule)
+	}
+	if d.Target != d.Path {
+		rule += " (" + d.Target + ")"
+	}
+
+	return fmt.Sprintf("%s\t%s\t%s\t%s", status, stage, rule, d.Path)
+}
+
+// Explain은 root를 문서화할 때 path가 포함되는지와 그 이유를 반환
+// Parse와 같은 순서로 루트부터 경로까지 내려가며 검사한 뒤 타입과 포함 패턴 필터를 적용
+func (d *directoryParser) Explain(root string, path string, types []string) (Decision, error) {
+	absRoot, err := filepath.Abs(root)
+	if err != nil {
+		return Decision{}, err
+	}
+	absPath, err := filepath.Abs(path)
+	if err != nil {
+		return Decision{}, err
+	}
+	relPath, err := filepath.Rel(absRoot, absPath)
+	if err != nil || relPath == "." || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+		return Decision{}, fmt.Errorf("루트 디렉토리 밖의 경로: %s", path)
+	}
+
+	info, statErr := os.Stat(absPath)
+	isDir := statErr == nil && info.IsDir()
+
+	ignorers := d.loadIgnorers(root)
+	parts := strings.Split(relPath, string(filepath.Separator))
+	current := root
+	var decision Decision
+	var link bool      // 내용 대신 링크로 기록되는 경로
+	var dupes []string // follow 방식에서 이미 내용을 모은 디렉토리라서 내려가지 않는 경로 (링크를 만나면 계산)
+	for i, part := range parts {
+		current = filepath.Join(current, part)
+		isLast := i == len(parts)-1
+
+		// follow는 Parse와 같은 탐색 순서로, 이미 내용을 모은 디렉토리를 가리키는 링크 안으로 내려가지 않음
+		if d.symlinks == SymlinkFollow && dupes == nil && isSymlink(current) {
+			if dupes, err = d.followDupes(root, ignorers); err != nil {
+				return Decision{}, err
+			}
+		}
+		if slices.Contains(dupes, current) {
+			if !isLast || !isSymlink(current) {
+				decision = Decision{Path: current, Target: current, Stage: StageSymlink, Rule: string(d.symlinks)}
+				break
+			}
+			link = true
+		}
+
+		// Parse와 같이 skip은 링크를 제외하고, record-as-link와 follow-files의 디렉토리 링크는 안으로 내려가지 않음
+		if d.symlinks != SymlinkFollow && isSymlink(current) {
+			dirLink := !isLast || isDir
+			if d.symlinks != SymlinkFollowFiles || dirLink {
+				if d.symlinks == SymlinkSkip || !isLast {
+					decision = Decision{Path: current, Target: current, Stage: StageSymlink, Rule: string(d.symlinks)}
+					break
+				}
+				link = true
+			}
+		}
+
+		// record-as-link는 링크 자체를 파일로 보고, 나머지는 디렉토리 링크를 디렉토리로 보고 규칙을 적용
+		decision = d.check(root, ignorers, current, (!link || d.symlinks != SymlinkRecord) && (!isLast || isDir))
+		if !decision.Included {
+			break
+		}
+	}
+	decision.Path = path
+	if decision.Included {
+		decision.Target = path
+	}
+	if decision.Included && link && decision.Stage == "" {
+		decision.Stage, decision.Rule = StageSymlink, string(d.symlinks)
+	}
+	if !decision.Included || isDir {
+		return decision, nil
+	}
+
+	// 타입과 포함 패턴 필터
+	selected := d.selectFile(absRoot, absPath, types)
+	if !selected.Included {
+		selected.Path, selected.Target = path, path
+		return selected, nil
+	}
+	if decision.Stage == "" {
+		decision.Stage, decision.Rule = selected.Stage, selected.Rule
+	}
+	return decision, nil
+}
+
+// followDupes는 follow 방식의 Parse가 이미 내용을 모은 디렉토리라서 내려가지 않는 경로들
+// 링크를 따라가는 순서에 따라 결정되므로 Parse와 같은 탐색을 실행해서 구함 (빈 결과도 nil이 아님)
+func (d *directoryParser) followDupes(root string, ignorers []ignore.Ignorer) ([]string, error) {
+	w := d.newWalker(root, ignorers)
+	w.keepGoing = true
+	if err := w.run(); err != nil {
+		return nil, err
+	}
+	return append([]string{}, w.dupes...), nil
+}
+
+// isSymlink는 경로가 심볼릭 링크인지 확인 (대상을 따라가지 않음)
+func isSymlink(path string) bool {
+	info, err := os.Lstat(path)
+	return err == nil && info.Mode()&os.ModeSymlink != 0
+}
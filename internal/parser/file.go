@@ -3,30 +3,69 @@ package parser
 import (
 	"io"
 	"os"
+	"strings"
 )
 
 // 파일 파서 구현체
-type fileParser struct{}
+type fileParser struct {
+	normalizeNewlines bool      // CRLF 줄바꿈을 LF로 변환할지 여부
+	limit             SizeLimit // 파일별 최대 크기
+}
 
 // 생성자 함수
 func NewFileParser() FileParser {
 	return &fileParser{}
 }
 
-// ReadContent 구현
+// CRLF 줄바꿈을 LF로 변환할지 설정
+func (fp *fileParser) SetNormalizeNewlines(normalize bool) {
+	fp.normalizeNewlines = normalize
+}
+
+// 파일별 최대 크기와 넘었을 때의 생략 방식 설정
+func (fp *fileParser) SetSizeLimit(limit SizeLimit) {
+	fp.limit = limit
+}
+
+// ReadContent 구현 (UTF-8로 변환한 내용)
 func (fp *fileParser) ReadContent(path string) (string, error) {
+	text, err := fp.ReadText(path)
+	if err != nil {
+		return "", err
+	}
+	return text.Content, nil
+}
+
+// ReadText 구현 (인코딩을 감지해서 UTF-8로 변환하고 크기 제한 적용)
+func (fp *fileParser) ReadText(path string) (TextContent, error) {
+	data, err := fp.ReadBytes(path)
+	if err != nil {
+		return TextContent{}, err
+	}
+
+	content, encoding := DecodeText(data)
+	if fp.normalizeNewlines {
+		content = strings.ReplaceAll(content, "\r\n", "\n")
+	}
+
+	content, truncation := fp.limit.truncate(path, content, int64(len(data)))
+	return TextContent{Content: content, Raw: data, Encoding: encoding, Truncation: truncation}, nil
+}
+
+// ReadBytes 구현 (변환 없이 원본 바이트)
+func (fp *fileParser) ReadBytes(path string) ([]byte, error) {
 	// 파일 열기
 	file, err := os.Open(path)
 	if err != nil {
-		return "", NewParseError(path, err)
+		return nil, NewParseError(path, err)
 	}
 	defer file.Close()
 
 	// 파일 내용 읽기
 	content, err := io.ReadAll(file)
 	if err != nil {
-		return "", NewParseError(path, err)
+		return nil, NewParseError(path, err)
 	}
 
-	return string(content), nil
+	return content, nil
 }
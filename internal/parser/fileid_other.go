@@ -0,0 +1,10 @@
+//go:build !unix
+
+package parser
+
+import "os"
+
+// fileIDOf는 장치 번호와 inode를 얻을 수 없는 플랫폼에서 항상 실패 (dirSet이 os.SameFile로 비교)
+func fileIDOf(info os.FileInfo) (fileID, bool) {
+	return fileID{}, false
+}
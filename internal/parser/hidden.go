@@ -0,0 +1,58 @@
+package parser
+
+import (
+	"path"
+	"strings"
+
+	"github.com/kihyun1998/codemd/pkg/utils"
+)
+
+// HiddenPolicy는 숨김 파일/디렉토리 처리 방식
+type HiddenPolicy struct {
+	IncludeDot     bool     // "."으로 시작하는 이름 포함
+	HideUnderscore bool     // "_"로 시작하는 이름도 숨김으로 처리
+	Allow          []string // 숨김이어도 포함할 이름, 글로브 또는 루트 기준 경로 (끝이 "/"면 디렉토리만)
+}
+
+// vcsDirs는 IncludeDot이어도 숨기는 버전 관리 디렉토리 (허용 목록으로만 포함)
+var vcsDirs = map[string]bool{".git": true, ".hg": true, ".svn": true}
+
+// isHidden은 정책상 숨김 이름인지 확인
+func (hp HiddenPolicy) isHidden(name string) bool {
+	if vcsDirs[name] {
+		return true
+	}
+	if !hp.IncludeDot && utils.IsDotFile(name) {
+		return true
+	}
+	return hp.HideUnderscore && utils.IsUnderscoreFile(name)
+}
+
+// allowed는 루트 기준 상대 경로(슬래시 구분)와 일치하는 허용 항목을 반환
+// 슬래시가 없는 항목은 어느 깊이에서든 이름과, 슬래시가 있는 항목은 루트 기준 경로와 비교
+func (hp HiddenPolicy) allowed(relPath string, isDir bool) (string, bool) {
+	for _, entry := range hp.Allow {
+		pattern := strings.TrimSpace(entry)
+		if pattern == "" {
+			continue
+		}
+
+		dirOnly := strings.HasSuffix(pattern, "/")
+		pattern = strings.TrimSuffix(pattern, "/")
+		if dirOnly && !isDir {
+			continue
+		}
+
+		target := relPath
+		if strings.Contains(pattern, "/") {
+			pattern = strings.TrimPrefix(pattern, "/")
+		} else {
+			target = path.Base(relPath)
+		}
+
+		if matched, err := path.Match(pattern, target); err == nil && matched {
+			return entry, true
+		}
+	}
+	return "", false
+}
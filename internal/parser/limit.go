@@ -0,0 +1,272 @@
+package parser
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// TruncateStrategy는 크기 제한을 넘는 파일의 처리 방식
+type TruncateStrategy string
+
+const (
+	TruncateSkip     TruncateStrategy = "skip"     // 내용을 넣지 않음
+	TruncateHead     TruncateStrategy = "head"     // 앞부분만 유지
+	TruncateHeadTail TruncateStrategy = "headtail" // 앞부분과 뒷부분을 유지하고 가운데를 생략
+	TruncateSummary  TruncateStrategy = "summary"  // 내용 대신 "(N lines omitted)"만 표시
+)
+
+// SizeLimit은 파일별 최대 크기 (0이면 제한 없음)
+type SizeLimit struct {
+	MaxBytes int64 // skip은 원본 파일 크기, 그 밖에는 UTF-8로 변환한 내용 기준
+	MaxLines int
+	Strategy TruncateStrategy
+}
+
+// Enabled는 제한이 설정되어 있는지 확인
+func (l SizeLimit) Enabled() bool {
+	return l.MaxBytes > 0 || l.MaxLines > 0
+}
+
+// exceeds는 크기와 줄 수가 제한을 넘는지 확인
+func (l SizeLimit) exceeds(size int64, lines int) bool {
+	return (l.MaxBytes > 0 && size > l.MaxBytes) || (l.MaxLines > 0 && lines > l.MaxLines)
+}
+
+// Truncation은 크기 제한으로 내용이 잘리거나 빠진 파일 정보
+type Truncation struct {
+	Path         string
+	Strategy     TruncateStrategy
+	Size         int64 // 원본 크기 (바이트)
+	Lines        int   // 원본 줄 수
+	OmittedLines int
+}
+
+// ParseSizeLimit는 "512KB", "2MB", "2000lines", "1MB,5000lines" 형식의 제한을 해석
+func ParseSizeLimit(value string, strategy string) (SizeLimit, error) {
+	limit := SizeLimit{Strategy: TruncateStrategy(strings.ToLower(strings.TrimSpace(strategy)))}
+	switch limit.Strategy {
+	case "":
+		limit.Strategy = TruncateHeadTail
+	case TruncateSkip, TruncateHead, TruncateHeadTail, TruncateSummary:
+	default:
+		return SizeLimit{}, fmt.Errorf("알 수 없는 생략 방식: %q (skip, head, headtail, summary 중 하나)", strategy)
+	}
+
+	units := []struct {
+		suffix string
+		size   int64
+	}{
+		{"GB", 1 << 30}, {"MB", 1 << 20}, {"KB", 1 << 10}, {"B", 1},
+	}
+
+	for _, entry := range strings.Split(value, ",") {
+		entry = strings.ToUpper(strings.TrimSpace(entry))
+		if entry == "" {
+			continue
+		}
+
+		if number, ok := strings.CutSuffix(entry, "LINES"); ok {
+			lines, err := strconv.Atoi(strings.TrimSpace(number))
+			if err != nil || lines <= 0 {
+				return SizeLimit{}, fmt.Errorf("잘못된 파일 크기 제한: %q", entry)
+			}
+			limit.MaxLines = lines
+			continue
+		}
+
+		parsed := false
+		for _, unit := range units {
+			if number, ok := strings.CutSuffix(entry, unit.suffix); ok {
+				size, err := strconv.ParseFloat(strings.TrimSpace(number), 64)
+				if err != nil || size <= 0 {
+					return SizeLimit{}, fmt.Errorf("잘못된 파일 크기 제한: %q", entry)
+				}
+				limit.MaxBytes = int64(size * float64(unit.size))
+				parsed = true
+				break
+			}
+		}
+		if !parsed {
+			return SizeLimit{}, fmt.Errorf("잘못된 파일 크기 제한: %q (예: 512KB, 2MB, 2000lines)", entry)
+		}
+	}
+	return limit, nil
+}
+
+// CheckSkip 구현 (skip 방식에서 제한을 넘는 파일이면 정보를 반환하고, 아니면 nil)
+func (fp *fileParser) CheckSkip(path string) (*Truncation, error) {
+	if !fp.limit.Enabled() || fp.limit.Strategy != TruncateSkip {
+		return nil, nil
+	}
+
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, NewParseError(path, err)
+	}
+
+	lines := 0
+	if fp.limit.MaxLines > 0 && (fp.limit.MaxBytes <= 0 || info.Size() <= fp.limit.MaxBytes) {
+		if lines, err = countFileLines(path); err != nil {
+			return nil, NewParseError(path, err)
+		}
+	}
+
+	if !fp.limit.exceeds(info.Size(), lines) {
+		return nil, nil
+	}
+	return &Truncation{Path: path, Strategy: TruncateSkip, Size: info.Size(), Lines: lines}, nil
+}
+
+// countFileLines는 파일 전체를 메모리에 올리지 않고 줄 수를 셈
+func countFileLines(path string) (int, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	buf := make([]byte, 32*1024)
+	lines, last := 0, byte('\n')
+	for {
+		n, err := reader.Read(buf)
+		if n > 0 {
+			lines += bytes.Count(buf[:n], []byte("\n"))
+			last = buf[n-1]
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+	}
+	if last != '\n' {
+		lines++ // 마지막 줄바꿈이 없는 줄
+	}
+	return lines, nil
+}
+
+// truncate는 제한을 넘는 내용을 생략 방식에 맞게 줄임 (제한 이내면 nil)
+// skip은 CheckSkip과 같이 원본 크기(size)로 판단하고, 그 밖에는 UTF-8로 변환한 내용 기준으로 자름
+func (l SizeLimit) truncate(path string, content string, size int64) (string, *Truncation) {
+	lines := CountLines(content)
+	measured := int64(len(content))
+	if l.Strategy == TruncateSkip {
+		measured = size
+	}
+	if !l.Enabled() || !l.exceeds(measured, lines) {
+		return content, nil
+	}
+
+	var head, tail string
+	switch l.Strategy {
+	case TruncateHead:
+		head = headOf(content, l.MaxLines, l.MaxBytes)
+	case TruncateHeadTail:
+		// 제한의 절반씩 앞뒤에 배분 (절반이 0이면 앞부분 없이 뒷부분만)
+		if (l.MaxLines == 0 || l.MaxLines/2 > 0) && (l.MaxBytes == 0 || l.MaxBytes/2 > 0) {
+			head = headOf(content, l.MaxLines/2, l.MaxBytes/2)
+		}
+		tail = tailOf(content[len(head):], l.MaxLines-l.MaxLines/2, l.MaxBytes-l.MaxBytes/2)
+	}
+
+	omitted := lines - CountLines(head) - CountLines(tail)
+	if omitted < 0 {
+		omitted = 0
+	}
+	truncation := &Truncation{Path: path, Strategy: l.Strategy, Size: size, Lines: lines, OmittedLines: omitted}
+
+	switch l.Strategy {
+	case TruncateSkip:
+		return "", truncation
+	case TruncateSummary:
+		return fmt.Sprintf("(%d lines omitted)", lines), truncation
+	}
+
+	var sb strings.Builder
+	sb.WriteString(head)
+	if head != "" && !strings.HasSuffix(head, "\n") {
+		sb.WriteString("\n")
+	}
+	sb.WriteString(fmt.Sprintf("... (%d lines omitted) ...", omitted))
+	if tail != "" {
+		sb.WriteString("\n" + tail)
+	}
+	return sb.String(), truncation
+}
+
+// headOf는 앞에서부터 maxLines줄, maxBytes바이트 이내의 내용 (0이면 제한 없음)
+// 바이트 제한에 걸리면 줄 단위로 자르고, 첫 줄부터 넘으면 문자 단위로 자름
+func headOf(content string, maxLines int, maxBytes int64) string {
+	head := content
+	if maxLines > 0 {
+		for i, n := 0, 0; i < len(head); i++ {
+			if head[i] == '\n' {
+				if n++; n == maxLines {
+					head = head[:i+1]
+					break
+				}
+			}
+		}
+	}
+	if maxBytes > 0 && int64(len(head)) > maxBytes {
+		head = head[:maxBytes]
+		if i := strings.LastIndexByte(head, '\n'); i >= 0 {
+			head = head[:i+1]
+		}
+		// 잘린 멀티바이트 문자 제거
+		start := len(head) - 1
+		for start > 0 && len(head)-start < utf8.UTFMax && !utf8.RuneStart(head[start]) {
+			start--
+		}
+		if start >= 0 && !utf8.FullRuneInString(head[start:]) {
+			head = head[:start]
+		}
+	}
+	return head
+}
+
+// tailOf는 뒤에서부터 maxLines줄, maxBytes바이트 이내의 내용 (0이면 제한 없음)
+func tailOf(content string, maxLines int, maxBytes int64) string {
+	tail := content
+	if maxLines > 0 {
+		body := strings.TrimSuffix(content, "\n")
+		for i, n := len(body)-1, 0; i >= 0; i-- {
+			if body[i] == '\n' {
+				if n++; n == maxLines {
+					tail = content[i+1:]
+					break
+				}
+			}
+		}
+	}
+	if maxBytes > 0 && int64(len(tail)) > maxBytes {
+		tail = tail[int64(len(tail))-maxBytes:]
+		if i := strings.IndexByte(tail, '\n'); i >= 0 && i < len(tail)-1 {
+			tail = tail[i+1:]
+		}
+		for i := 0; i < utf8.UTFMax-1 && tail != "" && !utf8.RuneStart(tail[0]); i++ {
+			tail = tail[1:]
+		}
+	}
+	return tail
+}
+
+// CountLines는 마지막 줄바꿈이 없는 줄도 한 줄로 세어 줄 수를 반환 (크기 제한과 템플릿의 줄 수에 함께 사용)
+func CountLines(content string) int {
+	if content == "" {
+		return 0
+	}
+	lines := strings.Count(content, "\n")
+	if !strings.HasSuffix(content, "\n") {
+		lines++
+	}
+	return lines
+}
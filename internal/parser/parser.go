@@ -4,8 +4,27 @@ type DirectoryParser interface {
 	Parse(root string) ([]string, error)
 	// FilterByExtenstion(files []string, ext string) []string
 	GetFilesByTypes(allFiles []string, types []string) []string
+	SelectFiles(root string, files []string, types []string) []string
+	SetIncludePatterns(patterns []string)
+	SetOutputPath(path string)
+	SetGitIgnore(use bool)
+	SetCodeIgnoreLegacy(legacy bool)
+	SetHiddenPolicy(policy HiddenPolicy)
+	SetJobs(jobs int)
+	SetKeepGoing(keep bool)
+	Skipped() []*ParseError
+	SetSymlinkMode(mode SymlinkMode)
+	Links() []Symlink
+	SelectLinks(root string, links []Symlink, types []string) []Symlink
+	Explain(root string, path string, types []string) (Decision, error)
 }
 
 type FileParser interface {
 	ReadContent(path string) (string, error)
+	ReadText(path string) (TextContent, error)
+	ReadBytes(path string) ([]byte, error)
+	IsBinary(path string) (bool, error)
+	SetNormalizeNewlines(normalize bool)
+	SetSizeLimit(limit SizeLimit)
+	CheckSkip(path string) (*Truncation, error)
 }
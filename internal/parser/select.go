@@ -0,0 +1,101 @@
+package parser
+
+import (
+	"path/filepath"
+	"strings"
+
+	"github.com/kihyun1998/codemd/internal/ignore"
+)
+
+// 출력할 파일을 고르는 glob 패턴 설정 (gitignore 문법, -type과 합집합)
+//   - 슬래시가 없는 패턴은 어느 깊이에서든 파일 이름과 매칭 (예: *_test.go, Dockerfile)
+//   - 슬래시가 있는 패턴은 루트 기준 경로와 매칭 (예: cmd/**/*.go)
+func (d *directoryParser) SetIncludePatterns(patterns []string) {
+	d.includes = nil
+	for _, pattern := range patterns {
+		if pattern = strings.TrimSpace(pattern); pattern != "" {
+			d.includes = append(d.includes, ignore.ParsePattern(pattern))
+		}
+	}
+}
+
+// root 아래의 파일들 중 -type 또는 -include 조건에 맞는 파일만 반환
+func (d *directoryParser) SelectFiles(root string, files []string, types []string) []string {
+	if !hasTypes(types) && len(d.includes) == 0 {
+		return files
+	}
+
+	var selected []string
+	for _, file := range files {
+		if d.selectFile(root, file, types).Included {
+			selected = append(selected, file)
+		}
+	}
+	return selected
+}
+
+// root 아래의 기록할 링크 중 프로젝트 구조에 표시할 링크만 반환
+// 디렉토리 링크는 항상, 파일 링크는 SelectFiles와 같은 조건에 맞을 때만 표시
+func (d *directoryParser) SelectLinks(root string, links []Symlink, types []string) []Symlink {
+	var selected []Symlink
+	for _, link := range links {
+		if link.IsDir || d.selectFile(root, link.Path, types).Included {
+			selected = append(selected, link)
+		}
+	}
+	return selected
+}
+
+// selectFile은 파일이 -type 또는 -include 조건에 맞는지와 매칭된 규칙을 반환
+// 조건이 없으면 단계 없이 포함
+func (d *directoryParser) selectFile(root string, path string, types []string) Decision {
+	if !hasTypes(types) && len(d.includes) == 0 {
+		return Decision{Path: path, Target: path, Included: true}
+	}
+
+	if matched, ok := matchType(path, types); ok {
+		return Decision{Path: path, Target: path, Included: true, Stage: StageType, Rule: matched}
+	}
+
+	relPath, err := filepath.Rel(root, path)
+	if err == nil {
+		relPath = filepath.ToSlash(relPath)
+		for _, pattern := range d.includes {
+			if pattern.IsMatch(relPath) {
+				return Decision{Path: path, Target: path, Included: true, Stage: StageInclude, Rule: pattern.String()}
+			}
+		}
+	}
+
+	// 어느 조건과도 맞지 않으면 지정된 조건 전체를 규칙으로 기록
+	stage, rules := StageInclude, []string(nil)
+	if hasTypes(types) {
+		stage, rules = StageType, append(rules, types...)
+	}
+	for _, pattern := range d.includes {
+		rules = append(rules, pattern.String())
+	}
+	return Decision{Path: path, Target: path, Stage: stage, Rule: strings.Join(rules, ",")}
+}
+
+// 타입이 지정되었는지 확인 (빈 -type은 모든 파일)
+func hasTypes(types []string) bool {
+	return !(len(types) == 0 || (len(types) == 1 && types[0] == ""))
+}
+
+// 파일 이름과 일치하는 타입 찾기 (대소문자 구분 없음)
+//   - "go", ".go", "d.ts": 이름이 ".타입"으로 끝나면 매칭 (점이 여러 개인 확장자 지원)
+//   - "Dockerfile", "Makefile": 이름 전체가 같으면 매칭
+func matchType(file string, types []string) (string, bool) {
+	name := strings.ToLower(filepath.Base(file))
+	for _, t := range types {
+		want := strings.ToLower(strings.TrimPrefix(strings.TrimSpace(t), "."))
+		if want == "" {
+			continue
+		}
+		if name == want || strings.HasSuffix(name, "."+want) {
+			return t, true
+		}
+	}
+	return "", false
+}
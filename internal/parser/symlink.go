@@ -0,0 +1,84 @@
+package parser
+
+import (
+	"fmt"
+	"os"
+	"strings"
+	"sync"
+)
+
+// SymlinkMode는 심볼릭 링크 처리 방식
+type SymlinkMode string
+
+const (
+	SymlinkSkip        SymlinkMode = "skip"           // 파일과 디렉토리 링크 모두 제외
+	SymlinkFollowFiles SymlinkMode = "follow-files"   // 파일 링크는 읽고, 디렉토리 링크는 내려가지 않고 "이름 -> 대상"으로 표시 (기본값)
+	SymlinkFollow      SymlinkMode = "follow"         // 대상을 읽고 디렉토리 링크 안으로도 내려감 (같은 디렉토리는 한 번만)
+	SymlinkRecord      SymlinkMode = "record-as-link" // 내용 없이 프로젝트 구조에 "이름 -> 대상"으로만 표시
+)
+
+// ParseSymlinkMode는 옵션 값을 심볼릭 링크 처리 방식으로 변환
+func ParseSymlinkMode(value string) (SymlinkMode, error) {
+	switch mode := SymlinkMode(strings.ToLower(strings.TrimSpace(value))); mode {
+	case "":
+		return SymlinkFollowFiles, nil
+	case SymlinkSkip, SymlinkFollowFiles, SymlinkFollow, SymlinkRecord:
+		return mode, nil
+	}
+	return "", fmt.Errorf("알 수 없는 심볼릭 링크 처리 방식: %q (skip, follow-files, follow, record-as-link 중 하나)", value)
+}
+
+// Symlink는 내용 대신 링크로 기록한 심볼릭 링크
+// record-as-link 방식의 모든 링크, follow-files 방식의 디렉토리 링크,
+// follow 방식에서 이미 내용을 모은 디렉토리(순환 포함)를 가리키는 디렉토리 링크
+type Symlink struct {
+	Path   string
+	Target string // 링크에 적힌 대상 경로 (os.Readlink 결과)
+	IsDir  bool   // 대상이 디렉토리인지 여부 (깨진 링크는 false)
+}
+
+// newSymlink는 링크 대상을 읽어서 Symlink를 만듦 (대상을 읽지 못하면 "?")
+func newSymlink(path string, isDir bool) Symlink {
+	target, err := os.Readlink(path)
+	if err != nil {
+		target = "?"
+	}
+	return Symlink{Path: path, Target: target, IsDir: isDir}
+}
+
+// fileID는 파일을 구별하는 장치 번호와 inode
+type fileID struct {
+	dev uint64
+	ino uint64
+}
+
+// dirSet은 이미 내용을 모은 디렉토리들 (장치 번호와 inode로 비교)
+// 장치 번호와 inode를 얻을 수 없는 플랫폼에서는 os.SameFile로 모든 디렉토리와 비교
+type dirSet struct {
+	mu     sync.Mutex
+	ids    map[fileID]bool
+	others []os.FileInfo
+}
+
+// add는 디렉토리를 추가하고, 이미 있는 디렉토리면 false를 반환
+func (s *dirSet) add(info os.FileInfo) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if id, ok := fileIDOf(info); ok {
+		if s.ids[id] {
+			return false
+		}
+		if s.ids == nil {
+			s.ids = make(map[fileID]bool)
+		}
+		s.ids[id] = true
+		return true
+	}
+	for _, seen := range s.others {
+		if os.SameFile(seen, info) {
+			return false
+		}
+	}
+	s.others = append(s.others, info)
+	return true
+}
@@ -0,0 +1,259 @@
+package parser
+
+import (
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+	"sync/atomic"
+)
+
+// walkFilter는 경로를 포함할지 판단 (false인 디렉토리는 내려가지 않음)
+type walkFilter func(path string, isDir bool) bool
+
+// dirLink는 follow 방식에서 일반 디렉토리를 모두 읽은 뒤에 따라갈 디렉토리 링크
+type dirLink struct {
+	path string
+	info os.FileInfo // 링크 대상 디렉토리
+}
+
+// walker는 디렉토리 큐를 jobs개의 작업자가 나눠 읽는 탐색기
+// filepath.WalkDir처럼 os.ReadDir의 DirEntry를 사용하므로 일반 항목마다 lstat하지 않으며,
+// 결과는 작업자 수와 관계없이 filepath.WalkDir의 방문 순서로 정렬됨
+type walker struct {
+	root      string
+	jobs      int
+	keepGoing bool        // 읽을 수 없는 하위 디렉토리를 건너뛰고 그 에러를 기록 (루트 에러는 항상 실패)
+	symlinks  SymlinkMode // 심볼릭 링크 처리 방식
+	filter    walkFilter
+
+	mu      sync.Mutex // 큐와 pending만 보호 (결과는 작업자마다 따로 모아서 마지막에 합침)
+	cond    *sync.Cond
+	queue   []string
+	pending int         // 큐에 있거나 읽는 중인 디렉토리 수
+	failed  atomic.Bool // 오류가 나서 새 디렉토리를 읽지 않음
+	seen    dirSet      // follow 방식에서 내용을 모은 디렉토리
+
+	files   []string
+	links   []Symlink
+	dupes   []string // follow 방식에서 이미 내용을 모은 디렉토리라서 내려가지 않은 경로 (링크와 링크 안의 디렉토리)
+	skipped []*ParseError
+	errs    []*ParseError
+}
+
+// walkOutput은 작업자 하나가 모은 결과
+type walkOutput struct {
+	files    []string
+	links    []Symlink
+	dirLinks []dirLink
+	dupes    []string
+	skipped  []*ParseError
+	errs     []*ParseError
+}
+
+// run은 root부터 탐색해서 filter를 통과한 파일과 기록할 링크를 모음
+// 오류가 나면 새 디렉토리를 읽지 않고, 경로가 가장 앞선 오류를 반환 (실행마다 같은 결과)
+func (w *walker) run() error {
+	if w.symlinks == SymlinkFollow {
+		info, err := os.Stat(w.root)
+		if err != nil {
+			return NewParseError(w.root, err)
+		}
+		w.seen.add(info)
+	}
+	w.cond = sync.NewCond(&w.mu)
+
+	// follow 방식은 일반 디렉토리를 모두 읽은 뒤 디렉토리 링크를 탐색 순서대로 하나씩 따라감
+	// 대상이 이미 내용을 모은 디렉토리(순환을 만드는 상위 디렉토리 포함)면 링크로만 기록하므로
+	// 같은 디렉토리의 내용은 일반 경로나 탐색 순서가 가장 앞선 링크 아래에 한 번만 나옴
+	dirLinks := w.walk(w.root)
+	for len(dirLinks) > 0 && !w.failed.Load() {
+		sort.Slice(dirLinks, func(i, j int) bool { return walkLess(dirLinks[i].path, dirLinks[j].path) })
+		link := dirLinks[0]
+		dirLinks = dirLinks[1:]
+		if !w.seen.add(link.info) {
+			w.links = append(w.links, newSymlink(link.path, true))
+			w.dupes = append(w.dupes, link.path)
+			continue
+		}
+		dirLinks = append(dirLinks, w.walk(link.path)...)
+	}
+
+	if len(w.errs) > 0 {
+		sort.Slice(w.errs, func(i, j int) bool { return walkLess(w.errs[i].Path, w.errs[j].Path) })
+		return w.errs[0]
+	}
+	sort.Slice(w.files, func(i, j int) bool { return walkLess(w.files[i], w.files[j]) })
+	sort.Slice(w.links, func(i, j int) bool { return walkLess(w.links[i].Path, w.links[j].Path) })
+	sort.Slice(w.skipped, func(i, j int) bool { return walkLess(w.skipped[i].Path, w.skipped[j].Path) })
+	return nil
+}
+
+// walk는 작업자들이 dir 아래를 나눠 읽게 하고, 결과를 모은 뒤 따라갈 디렉토리 링크를 반환
+func (w *walker) walk(dir string) []dirLink {
+	w.queue = []string{dir}
+	w.pending = 1
+
+	outputs := make([]walkOutput, max(w.jobs, 1))
+	var wg sync.WaitGroup
+	for i := range outputs {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.work(&outputs[i])
+		}()
+	}
+	wg.Wait()
+
+	var dirLinks []dirLink
+	for _, out := range outputs {
+		w.files = append(w.files, out.files...)
+		w.links = append(w.links, out.links...)
+		w.dupes = append(w.dupes, out.dupes...)
+		w.skipped = append(w.skipped, out.skipped...)
+		w.errs = append(w.errs, out.errs...)
+		dirLinks = append(dirLinks, out.dirLinks...)
+	}
+	return dirLinks
+}
+
+// work는 큐가 비고 읽는 중인 디렉토리가 없을 때까지 디렉토리를 하나씩 꺼내 읽고 결과를 out에 모음
+// 잠금은 큐에서 꺼내고 넣을 때만 잡으며, 새 디렉토리 수만큼만 대기 중인 작업자를 깨움
+func (w *walker) work(out *walkOutput) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	for {
+		for len(w.queue) == 0 && w.pending > 0 {
+			w.cond.Wait()
+		}
+		if w.pending == 0 {
+			return
+		}
+
+		dir := w.queue[len(w.queue)-1]
+		w.queue = w.queue[:len(w.queue)-1]
+		w.mu.Unlock()
+
+		var result dirResult
+		if !w.failed.Load() {
+			result = w.readDir(dir)
+		}
+		if err := result.err; err != nil {
+			if w.keepGoing && dir != w.root {
+				out.skipped = append(out.skipped, err)
+			} else {
+				out.errs = append(out.errs, err)
+				w.failed.Store(true)
+			}
+		}
+		out.files = append(out.files, result.files...)
+		out.links = append(out.links, result.links...)
+		out.dirLinks = append(out.dirLinks, result.dirLinks...)
+		out.dupes = append(out.dupes, result.dupes...)
+
+		w.mu.Lock()
+		w.queue = append(w.queue, result.subdirs...)
+		w.pending += len(result.subdirs) - 1
+		if w.pending == 0 {
+			w.cond.Broadcast() // 탐색이 끝났으므로 모든 작업자를 종료
+			continue
+		}
+		// 이 작업자가 하나를 바로 꺼내므로 나머지 디렉토리 수만큼만 깨움
+		for i := 1; i < len(result.subdirs); i++ {
+			w.cond.Signal()
+		}
+	}
+}
+
+// dirResult는 디렉토리 하나를 읽은 결과
+type dirResult struct {
+	subdirs  []string
+	files    []string
+	links    []Symlink
+	dirLinks []dirLink
+	dupes    []string
+	err      *ParseError
+}
+
+// readDir는 디렉토리 항목을 읽어서 filter를 통과한 하위 디렉토리, 파일, 기록할 링크로 나눔
+func (w *walker) readDir(dir string) dirResult {
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return dirResult{err: &ParseError{Path: dir, Err: err}}
+	}
+
+	var result dirResult
+	for _, entry := range entries {
+		path := filepath.Join(dir, entry.Name())
+		isDir := entry.IsDir()
+
+		if entry.Type()&fs.ModeSymlink != 0 {
+			switch w.symlinks {
+			case SymlinkSkip:
+				continue
+			case SymlinkRecord:
+				// git처럼 링크 자체를 파일로 보고 규칙을 적용
+				if w.filter(path, false) {
+					target, err := os.Stat(path)
+					result.links = append(result.links, newSymlink(path, err == nil && target.IsDir()))
+				}
+				continue
+			}
+
+			// 파일 링크와 깨진 링크는 파일로 취급 (깨진 링크는 읽을 때 에러가 남)
+			if target, err := os.Stat(path); err == nil && target.IsDir() {
+				if !w.filter(path, true) {
+					continue
+				}
+				if w.symlinks == SymlinkFollow {
+					result.dirLinks = append(result.dirLinks, dirLink{path: path, info: target})
+				} else {
+					result.links = append(result.links, newSymlink(path, true))
+				}
+				continue
+			}
+		}
+
+		if !w.filter(path, isDir) {
+			continue
+		}
+		if !isDir {
+			result.files = append(result.files, path)
+			continue
+		}
+
+		// 링크를 따라간 경로 안에서 이미 내용을 모은 디렉토리를 만나면 다시 내려가지 않음
+		if w.symlinks == SymlinkFollow {
+			info, err := entry.Info()
+			if err != nil {
+				continue // 읽는 사이에 삭제됨
+			}
+			if !w.seen.add(info) {
+				result.dupes = append(result.dupes, path)
+				continue
+			}
+		}
+		result.subdirs = append(result.subdirs, path)
+	}
+	return result
+}
+
+// walkLess는 a가 filepath.WalkDir에서 b보다 먼저 방문되는지 확인
+// 경로 구분자를 가장 작은 바이트로 보고 비교하면 디렉토리 내용이 같은 접두사의 형제보다 앞에 옴
+func walkLess(a, b string) bool {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		ca, cb := a[i], b[i]
+		if ca == cb {
+			continue
+		}
+		if ca == filepath.Separator {
+			return true
+		}
+		if cb == filepath.Separator {
+			return false
+		}
+		return ca < cb
+	}
+	return len(a) < len(b)
+}
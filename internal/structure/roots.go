@@ -0,0 +1,117 @@
+package structure
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+)
+
+// RootLabels는 각 루트 디렉토리의 표시 이름을 반환 (이름이 겹치면 -2, -3을 붙임)
+func RootLabels(rootPaths []string) []string {
+	labels := make([]string, len(rootPaths))
+	seen := make(map[string]int)
+	for i, root := range rootPaths {
+		label := filepath.Base(root)
+		seen[label]++
+		if seen[label] > 1 {
+			label = fmt.Sprintf("%s-%d", label, seen[label])
+		}
+		labels[i] = label
+	}
+	return labels
+}
+
+// OwnerRoot는 경로를 포함하는 가장 깊은 루트의 인덱스를 반환 (없으면 -1)
+func OwnerRoot(rootPaths []string, path string) int {
+	owner, depth := -1, -1
+	for i, root := range rootPaths {
+		relPath, err := filepath.Rel(root, path)
+		if err != nil || relPath == ".." || strings.HasPrefix(relPath, ".."+string(filepath.Separator)) {
+			continue
+		}
+		if len(root) > depth {
+			owner, depth = i, len(root)
+		}
+	}
+	return owner
+}
+
+// multiRootTree는 여러 루트 디렉토리를 각각의 섹션으로 표현하는 Tree 구현체
+type multiRootTree struct {
+	rootPaths []string
+	labels    []string
+	trees     []*directoryTree
+}
+
+// NewMultiRootTree는 루트마다 별도의 트리를 가진 Tree 인스턴스를 생성
+func NewMultiRootTree(rootPaths []string) Tree {
+	mt := &multiRootTree{
+		rootPaths: rootPaths,
+		labels:    RootLabels(rootPaths),
+	}
+	for i, root := range rootPaths {
+		tree := NewDirectoryTree(root).(*directoryTree)
+		tree.root.Name = mt.labels[i]
+		mt.trees = append(mt.trees, tree)
+	}
+	return mt
+}
+
+func (mt *multiRootTree) BuildTree(files []string) error {
+	groups := make([][]string, len(mt.trees))
+	for _, file := range files {
+		owner := OwnerRoot(mt.rootPaths, file)
+		if owner < 0 {
+			return fmt.Errorf("루트 디렉토리 밖의 파일: %s", file)
+		}
+		groups[owner] = append(groups[owner], file)
+	}
+
+	for i, tree := range mt.trees {
+		if err := tree.BuildTree(groups[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Annotate는 파일을 포함하는 루트의 트리에서 파일 노드에 설명을 붙임
+func (mt *multiRootTree) Annotate(file string, note string) {
+	if owner := OwnerRoot(mt.rootPaths, file); owner >= 0 {
+		mt.trees[owner].Annotate(file, note)
+	}
+}
+
+// AddLink는 링크를 포함하는 루트의 트리에 심볼릭 링크 노드를 추가
+func (mt *multiRootTree) AddLink(link string, target string) error {
+	owner := OwnerRoot(mt.rootPaths, link)
+	if owner < 0 {
+		return fmt.Errorf("루트 디렉토리 밖의 파일: %s", link)
+	}
+	return mt.trees[owner].AddLink(link, target)
+}
+
+// ToMarkdown은 루트마다 하위 섹션으로 나눈 트리구조를 마크다운으로 변환
+func (mt *multiRootTree) ToMarkdown() string {
+	var sb strings.Builder
+	sb.WriteString("## Project Structure\n\n")
+	for i, tree := range mt.trees {
+		sb.WriteString("### " + mt.labels[i] + "\n\n")
+		sb.WriteString(tree.codeBlock())
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// ToLinkedMarkdown은 루트마다 하위 섹션으로 나눈 목록을 만들고 파일 노드를 앵커로 연결
+// anchors는 "루트 이름/상대 경로" 형식의 경로별 앵커
+func (mt *multiRootTree) ToLinkedMarkdown(anchors map[string]string) string {
+	var sb strings.Builder
+	sb.WriteString("## Project Structure\n\n")
+	for i, tree := range mt.trees {
+		sb.WriteString("### " + mt.labels[i] + "\n\n")
+		sb.WriteString(tree.linkedList(mt.labels[i], anchors))
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
@@ -11,15 +11,37 @@ import (
 type Tree interface {
 	BuildTree(files []string) error
 	ToMarkdown() string
+	ToLinkedMarkdown(anchors map[string]string) string
+	Annotate(file string, note string)
+	AddLink(link string, target string) error
 }
 
 // Node는 파일 시스템의 노드를 표현
 type Node struct {
 	Name     string
 	IsDir    bool
+	Note     string // 이름 뒤에 괄호로 표시할 설명 (예: "binary, 2.3 MB")
+	Link     string // 심볼릭 링크로 기록된 노드의 대상 (이름 뒤에 "-> 대상"으로 표시)
 	Children map[string]*Node
 }
 
+// label은 노드 이름 뒤에 링크 대상과 설명을 붙인 표시 이름
+func (n *Node) label() string {
+	return n.Name + n.suffix()
+}
+
+// suffix는 이름 뒤에 붙는 링크 대상과 설명
+func (n *Node) suffix() string {
+	var suffix string
+	if n.Link != "" {
+		suffix += " -> " + n.Link
+	}
+	if n.Note != "" {
+		suffix += " (" + n.Note + ")"
+	}
+	return suffix
+}
+
 // directoryTree는 Tree 인터페이스 구현체
 type directoryTree struct {
 	root     *Node
@@ -40,41 +62,119 @@ func NewDirectoryTree(rootPath string) Tree {
 
 func (dt *directoryTree) BuildTree(files []string) error {
 	for _, file := range files {
-		relPath, err := filepath.Rel(dt.rootPath, file)
-		if err != nil {
-			return fmt.Errorf("상대 경로 계산 실패: %w", err)
+		if _, err := dt.insert(file); err != nil {
+			return err
 		}
+	}
+	return nil
+}
+
+// insert는 파일 노드와 상위 디렉토리 노드들을 만들고 파일 노드를 반환
+func (dt *directoryTree) insert(file string) (*Node, error) {
+	relPath, err := filepath.Rel(dt.rootPath, file)
+	if err != nil {
+		return nil, fmt.Errorf("상대 경로 계산 실패: %w", err)
+	}
+
+	parts := strings.Split(filepath.ToSlash(relPath), "/")
+	current := dt.root
 
-		parts := strings.Split(filepath.ToSlash(relPath), "/")
-		current := dt.root
-
-		for i, part := range parts {
-			isLast := i == len(parts)-1
-			if _, exists := current.Children[part]; !exists {
-				current.Children[part] = &Node{
-					Name:     part,
-					IsDir:    !isLast,
-					Children: make(map[string]*Node),
-				}
+	for i, part := range parts {
+		isLast := i == len(parts)-1
+		if _, exists := current.Children[part]; !exists {
+			current.Children[part] = &Node{
+				Name:     part,
+				IsDir:    !isLast,
+				Children: make(map[string]*Node),
 			}
-			current = current.Children[part]
 		}
+		current = current.Children[part]
 	}
+	return current, nil
+}
+
+// AddLink는 심볼릭 링크를 내용 없이 "이름 -> 대상" 형태의 노드로 추가 (디렉토리 링크도 내려가지 않음)
+func (dt *directoryTree) AddLink(link string, target string) error {
+	node, err := dt.insert(link)
+	if err != nil {
+		return err
+	}
+	node.Link = target
 	return nil
 }
 
+// Annotate는 트리에 있는 파일 노드에 설명을 붙임 (트리에 없는 파일은 무시)
+func (dt *directoryTree) Annotate(file string, note string) {
+	relPath, err := filepath.Rel(dt.rootPath, file)
+	if err != nil {
+		return
+	}
+
+	current := dt.root
+	for _, part := range strings.Split(filepath.ToSlash(relPath), "/") {
+		child, exists := current.Children[part]
+		if !exists {
+			return
+		}
+		current = child
+	}
+	current.Note = note
+}
+
 // ToMarkdown은 트리구조를 마크다운으로 변환하는 함수
 func (dt *directoryTree) ToMarkdown() string {
+	return "## Project Structure\n\n" + dt.codeBlock() + "\n"
+}
+
+// codeBlock은 트리를 코드 블록으로 그림
+func (dt *directoryTree) codeBlock() string {
 	var sb strings.Builder
-	sb.WriteString("## Project Structure\n\n")
 	sb.WriteString("```\n")
 	sb.WriteString(dt.root.Name + "/\n")
 	dt.writeNode(&sb, dt.root, "", true)
-	sb.WriteString("```\n\n")
+	sb.WriteString("```\n")
 	return sb.String()
 }
 
-func (dt *directoryTree) writeNode(sb *strings.Builder, node *Node, prefix string, isLast bool) {
+// ToLinkedMarkdown은 트리구조를 코드 블록 밖의 목록으로 변환하고, 파일 노드를 앵커로 연결
+// anchors는 루트 기준 상대 경로(슬래시 구분)별 앵커
+func (dt *directoryTree) ToLinkedMarkdown(anchors map[string]string) string {
+	return "## Project Structure\n\n" + dt.linkedList("", anchors) + "\n"
+}
+
+// linkedList는 트리를 앵커 링크가 달린 목록으로 그림 (relPrefix는 앵커 키 앞에 붙는 경로)
+func (dt *directoryTree) linkedList(relPrefix string, anchors map[string]string) string {
+	var sb strings.Builder
+	sb.WriteString("- " + dt.root.Name + "/\n")
+	dt.writeLinkedNode(&sb, dt.root, relPrefix, "  ", anchors)
+	return sb.String()
+}
+
+func (dt *directoryTree) writeLinkedNode(sb *strings.Builder, node *Node, relDir string, indent string, anchors map[string]string) {
+	for _, child := range sortedChildren(node) {
+		relPath := child.Name
+		if relDir != "" {
+			relPath = relDir + "/" + child.Name
+		}
+
+		sb.WriteString(indent + "- ")
+		if child.IsDir {
+			sb.WriteString(child.Name + "/\n")
+			dt.writeLinkedNode(sb, child, relPath, indent+"  ", anchors)
+			continue
+		}
+
+		if anchor, ok := anchors[relPath]; ok {
+			sb.WriteString(fmt.Sprintf("[%s](#%s)", child.Name, anchor))
+		} else {
+			sb.WriteString(child.Name)
+		}
+		sb.WriteString(child.suffix() + "\n")
+	}
+}
+
+// sortedChildren은 디렉토리를 먼저, 그 다음 이름순으로 자식 노드를 정렬
+func sortedChildren(node *Node) []*Node {
 	children := make([]*Node, 0, len(node.Children))
 	for _, child := range node.Children {
 		children = append(children, child)
@@ -85,6 +185,11 @@ func (dt *directoryTree) writeNode(sb *strings.Builder, node *Node, prefix strin
 		}
 		return children[i].Name < children[j].Name
 	})
+	return children
+}
+
+func (dt *directoryTree) writeNode(sb *strings.Builder, node *Node, prefix string, isLast bool) {
+	children := sortedChildren(node)
 
 	for i, child := range children {
 		isLastChild := i == len(children)-1
@@ -105,7 +210,7 @@ func (dt *directoryTree) writeNode(sb *strings.Builder, node *Node, prefix strin
 		if child.IsDir {
 			sb.WriteString(child.Name + "/\n")
 		} else {
-			sb.WriteString(child.Name + "\n")
+			sb.WriteString(child.label() + "\n")
 		}
 
 		if child.IsDir {
@@ -0,0 +1,112 @@
+package token
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Counter는 텍스트의 토큰 수를 계산하는 인터페이스
+type Counter interface {
+	Count(text string) int64
+}
+
+// 토큰 하나에 해당하는 평균 글자 수 (cl100k 기준 보정값)
+const (
+	lettersPerToken = 6
+	digitsPerToken  = 3
+	punctsPerToken  = 2
+)
+
+// estimator는 cl100k 계열 BPE 토크나이저를 근사하는 Counter 구현체
+type estimator struct{}
+
+// NewEstimator는 외부 사전 없이 동작하는 토큰 수 추정기를 생성
+func NewEstimator() Counter {
+	return &estimator{}
+}
+
+// Count는 BPE의 사전 분할 규칙(단어, 숫자, 공백, 기호)을 흉내 내어 토큰 수를 추정
+func (e *estimator) Count(text string) int64 {
+	var tokens int64
+
+	for i := 0; i < len(text); {
+		r, size := utf8.DecodeRuneInString(text[i:])
+
+		switch {
+		case isASCIILetter(r):
+			// camelCase 경계에서 나누고, 긴 조각은 여러 토큰으로 취급
+			n := 1
+			for i+n < len(text) && isASCIILetter(rune(text[i+n])) {
+				if isUpper(text[i+n]) && !isUpper(text[i+n-1]) {
+					break
+				}
+				n++
+			}
+			tokens += ceilDiv(n, lettersPerToken)
+			i += n
+
+		case isDigit(r):
+			n := runLength(text[i:], isDigit)
+			tokens += ceilDiv(n, digitsPerToken)
+			i += n
+
+		case r == ' ' && i+1 < len(text) && isASCIILetter(rune(text[i+1])):
+			// 단어 앞의 공백 하나는 단어 토큰에 합쳐짐
+			i++
+
+		case unicode.IsSpace(r):
+			// 들여쓰기와 줄바꿈 묶음은 하나의 토큰
+			n := runLength(text[i:], unicode.IsSpace)
+			tokens++
+			i += n
+
+		case r < utf8.RuneSelf:
+			n := runLength(text[i:], isASCIIPunct)
+			tokens += ceilDiv(n, punctsPerToken)
+			i += n
+
+		default:
+			// 한글, 한자 등 비 ASCII 문자는 글자당 하나의 토큰
+			tokens++
+			i += size
+		}
+	}
+
+	return tokens
+}
+
+// runLength는 문자열 앞부분에서 조건을 만족하는 연속된 바이트 수를 반환
+func runLength(s string, match func(rune) bool) int {
+	n := 0
+	for n < len(s) {
+		r, size := utf8.DecodeRuneInString(s[n:])
+		if !match(r) {
+			break
+		}
+		n += size
+	}
+	if n == 0 {
+		_, n = utf8.DecodeRuneInString(s)
+	}
+	return n
+}
+
+func ceilDiv(n, d int) int64 {
+	return int64((n + d - 1) / d)
+}
+
+func isASCIILetter(r rune) bool {
+	return (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z')
+}
+
+func isUpper(b byte) bool {
+	return b >= 'A' && b <= 'Z'
+}
+
+func isDigit(r rune) bool {
+	return r >= '0' && r <= '9'
+}
+
+func isASCIIPunct(r rune) bool {
+	return r < utf8.RuneSelf && !isASCIILetter(r) && !isDigit(r) && !unicode.IsSpace(r)
+}
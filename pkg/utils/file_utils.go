@@ -5,10 +5,19 @@ import (
 	"strings"
 )
 
-// 숨김 파일/디렉토리 체크
+// 숨김 파일/디렉토리 체크 ("." 또는 "_"로 시작)
 func IsHidden(path string) bool {
-	name := strings.TrimSpace(path)
-	return strings.HasPrefix(name, ".") || strings.HasPrefix(name, "_")
+	return IsDotFile(path) || IsUnderscoreFile(path)
+}
+
+// "."으로 시작하는 파일/디렉토리 체크
+func IsDotFile(path string) bool {
+	return strings.HasPrefix(strings.TrimSpace(path), ".")
+}
+
+// "_"로 시작하는 파일/디렉토리 체크
+func IsUnderscoreFile(path string) bool {
+	return strings.HasPrefix(strings.TrimSpace(path), "_")
 }
 
 // 파일 정보 구조체
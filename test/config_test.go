@@ -0,0 +1,99 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/kihyun1998/codemd/internal/config"
+	"github.com/kihyun1998/codemd/internal/parser"
+)
+
+func TestValidateRootDirs(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, dir := range []string{"a/sub", "b", "ab"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "file.go"), []byte("package x\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	link := filepath.Join(tempDir, "link")
+	hasLink := os.Symlink(filepath.Join(tempDir, "a"), link) == nil
+
+	dir := func(name string) string { return filepath.Join(tempDir, name) }
+	tests := []struct {
+		name    string
+		roots   []string
+		wantErr bool
+	}{
+		{"서로 다른 루트", []string{dir("a"), dir("b"), dir("ab")}, false},
+		{"같은 루트", []string{dir("a"), dir("a") + string(filepath.Separator)}, true},
+		{"다른 루트 안의 루트", []string{dir("a"), dir("a/sub")}, true},
+		{"다른 루트를 포함하는 루트", []string{dir("a/sub"), dir("a")}, true},
+		{"없는 디렉토리", []string{dir("missing")}, true},
+		{"파일", []string{dir("file.go")}, true},
+		{"심볼릭 링크로 지정한 같은 루트", []string{dir("a"), link}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.roots[len(tt.roots)-1] == link && !hasLink {
+				t.Skip("심볼릭 링크를 만들 수 없음")
+			}
+			if err := config.ValidateRootDirs(tt.roots); (err != nil) != tt.wantErr {
+				t.Errorf("ValidateRootDirs(%v) error = %v, wantErr %v", tt.roots, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestParseCheckIgnoreFlags(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, dir := range []string{"a/sub", "b"} {
+		if err := os.MkdirAll(filepath.Join(tempDir, dir), 0755); err != nil {
+			t.Fatal(err)
+		}
+	}
+	a, b := filepath.Join(tempDir, "a"), filepath.Join(tempDir, "b")
+
+	cfg, err := config.ParseCheckIgnoreFlags("codemd", []string{"-root", a + "," + b, "-symlinks", "skip", "-e", "vendor", "x.go"})
+	if err != nil {
+		t.Fatalf("ParseCheckIgnoreFlags() error = %v", err)
+	}
+	if len(cfg.RootDirs) != 2 || cfg.RootDirs[0] != a || cfg.RootDirs[1] != b {
+		t.Errorf("RootDirs = %v, want [%s %s]", cfg.RootDirs, a, b)
+	}
+	if cfg.Symlinks != parser.SymlinkSkip {
+		t.Errorf("Symlinks = %q, want skip", cfg.Symlinks)
+	}
+
+	// 파일 선택 플래그는 메인 명령과 같은 이름과 기본값
+	cfg, err = config.ParseCheckIgnoreFlags("codemd", []string{
+		"-root", a, "-t", "go", "-i", "Makefile", "-o", "out.md", "-c", "-g", "-codeignore-legacy",
+		"-include-hidden", "-hidden-allow", ".github/", "x.go",
+	})
+	if err != nil {
+		t.Fatalf("ParseCheckIgnoreFlags() error = %v", err)
+	}
+	if fmt.Sprintf("%v %v %s", cfg.FileTypes, cfg.Includes, cfg.OutputPath) != "[go] [Makefile] out.md" ||
+		!cfg.UseCodeIgnore || !cfg.UseGitIgnore || !cfg.LegacyNegate ||
+		!cfg.Hidden.IncludeDot || fmt.Sprint(cfg.Hidden.Allow) != "[.github/]" {
+		t.Errorf("ParseCheckIgnoreFlags() = %+v", cfg)
+	}
+	if cfg.Symlinks != parser.SymlinkFollowFiles {
+		t.Errorf("Symlinks = %q, want follow-files", cfg.Symlinks)
+	}
+
+	// 메인 명령과 같이 겹치는 루트와 잘못된 링크 처리 방식은 에러
+	for _, args := range [][]string{
+		{"-root", a + "," + filepath.Join(a, "sub"), "x.go"},
+		{"-root", a, "-symlinks", "bogus", "x.go"},
+	} {
+		if _, err := config.ParseCheckIgnoreFlags("codemd", args); err == nil {
+			t.Errorf("ParseCheckIgnoreFlags(%v) should fail", args)
+		}
+	}
+}
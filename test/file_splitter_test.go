@@ -1,9 +1,12 @@
 package test
 
 import (
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
+	"unicode/utf8"
 
 	"github.com/kihyun1998/codemd/internal/file"
 )
@@ -72,3 +75,188 @@ func TestFileSplitter(t *testing.T) {
 		})
 	}
 }
+
+func TestSplitSectionsKeepsFileBoundaries(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "CODE.md")
+
+	sections := []file.Section{
+		{Content: "# Project\n"},
+		{Title: "a.go", Content: "## a.go\n```go\npackage a\n```\n"},
+		{Title: "b.go", Content: "## b.go\n```go\npackage b\n```\n"},
+		{Title: "c.go", Content: "## c.go\n```go\npackage c\n```\n"},
+	}
+
+	// 섹션 두 개 정도가 들어가는 크기
+	splitter := file.NewFileSplitterWithBytes(60)
+	if err := splitter.SplitSections(sections, testFile); err != nil {
+		t.Fatalf("SplitSections() error = %v", err)
+	}
+
+	var joined string
+	for i := 1; ; i++ {
+		data, err := os.ReadFile(filepath.Join(tempDir, fmt.Sprintf("CODE%d.md", i)))
+		if err != nil {
+			break
+		}
+		part := string(data)
+		if strings.Count(part, "```")%2 != 0 {
+			t.Errorf("파트 %d의 코드 펜스가 닫히지 않음: %q", i, part)
+		}
+		if len(part) > 60 {
+			t.Errorf("파트 %d의 크기가 제한을 초과함: %d", i, len(part))
+		}
+		joined += part
+	}
+
+	var want string
+	for _, s := range sections {
+		want += s.Content
+	}
+	if joined != want {
+		t.Errorf("분할된 파트를 합친 결과가 원본과 다름.\ngot  %q\nwant %q", joined, want)
+	}
+}
+
+func TestSplitSectionsOversizedSection(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "CODE.md")
+
+	var body strings.Builder
+	for i := 0; i < 20; i++ {
+		body.WriteString("// 한글 주석이 포함된 줄입니다\n")
+	}
+	content := "## big.go\n```go\n" + body.String() + "```\n"
+
+	splitter := file.NewFileSplitterWithBytes(200)
+	if err := splitter.SplitSections([]file.Section{{Title: "big.go", Content: content}}, testFile); err != nil {
+		t.Fatalf("SplitSections() error = %v", err)
+	}
+
+	second, err := os.ReadFile(filepath.Join(tempDir, "CODE2.md"))
+	if err != nil {
+		t.Fatalf("두 번째 파트를 찾을 수 없음: %v", err)
+	}
+	if !strings.HasPrefix(string(second), "## big.go (continued, part 2)\n\n```go\n") {
+		t.Errorf("이어지는 파트의 헤더가 잘못됨: %q", second)
+	}
+
+	for i := 1; ; i++ {
+		data, err := os.ReadFile(filepath.Join(tempDir, fmt.Sprintf("CODE%d.md", i)))
+		if err != nil {
+			break
+		}
+		if !utf8.Valid(data) {
+			t.Errorf("파트 %d에 깨진 UTF-8 문자가 있음", i)
+		}
+		if strings.Count(string(data), "```")%2 != 0 {
+			t.Errorf("파트 %d의 코드 펜스가 닫히지 않음: %q", i, data)
+		}
+	}
+}
+
+func TestSplitIfNeededRuneBoundary(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "CODE.md")
+
+	// 줄바꿈 없는 멀티바이트 문자열은 문자 경계에서만 잘려야 함
+	content := strings.Repeat("가", 100)
+
+	splitter := file.NewFileSplitterWithBytes(10)
+	if err := splitter.SplitIfNeeded(content, testFile); err != nil {
+		t.Fatalf("SplitIfNeeded() error = %v", err)
+	}
+
+	var joined string
+	for i := 1; ; i++ {
+		data, err := os.ReadFile(filepath.Join(tempDir, fmt.Sprintf("CODE%d.md", i)))
+		if err != nil {
+			break
+		}
+		if !utf8.Valid(data) {
+			t.Errorf("파트 %d에 깨진 UTF-8 문자가 있음", i)
+		}
+		joined += string(data)
+	}
+	if joined != content {
+		t.Errorf("분할된 파트를 합친 결과가 원본과 다름")
+	}
+}
+
+func TestSplitSectionsPartHeaderAndIndex(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "CODE.md")
+
+	sections := []file.Section{
+		{Content: "# demo\n"},
+		{Title: "a.go", Content: "## a.go\n```go\npackage a\n```\n"},
+		{Title: "b.go", Content: "## b.go\n```go\npackage b\n```\n"},
+		{Title: "c.go", Content: "## c.go\n```go\npackage c\n```\n"},
+		{Title: "d.go", Content: "## d.go\n```go\npackage d\n```\n"},
+		{Title: "e.go", Content: "## e.go\n```go\npackage e\n```\n"},
+		{Title: "f.go", Content: "## f.go\n```go\npackage f\n```\n"},
+	}
+
+	splitter := file.NewFileSplitterWithBytes(150)
+	splitter.SetPartHeader(&file.PartHeader{ProjectName: "demo", Structure: "## Project Structure\n"})
+	if err := splitter.SplitSections(sections, testFile); err != nil {
+		t.Fatalf("SplitSections() error = %v", err)
+	}
+
+	var total int
+	for total = 0; ; total++ {
+		if _, err := os.Stat(filepath.Join(tempDir, fmt.Sprintf("CODE%d.md", total+1))); err != nil {
+			break
+		}
+	}
+	if total < 2 {
+		t.Fatalf("파일이 분할되지 않음: %d", total)
+	}
+
+	for i := 1; i <= total; i++ {
+		data, err := os.ReadFile(filepath.Join(tempDir, fmt.Sprintf("CODE%d.md", i)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		part := string(data)
+		if !strings.HasPrefix(part, fmt.Sprintf("> demo — Part %d of %d\n", i, total)) {
+			t.Errorf("파트 %d의 머리말이 잘못됨: %q", i, part)
+		}
+		if i > 1 && !strings.Contains(part, "## Project Structure\n") {
+			t.Errorf("파트 %d에 프로젝트 구조가 반복되지 않음", i)
+		}
+		if len(part) > 150 {
+			t.Errorf("파트 %d의 크기가 제한을 초과함: %d", i, len(part))
+		}
+	}
+
+	index, err := os.ReadFile(filepath.Join(tempDir, "CODE.index.md"))
+	if err != nil {
+		t.Fatalf("인덱스 파일을 찾을 수 없음: %v", err)
+	}
+	for _, path := range []string{"a.go", "b.go", "c.go", "d.go", "e.go", "f.go"} {
+		if !strings.Contains(string(index), "| "+path+" | CODE") {
+			t.Errorf("인덱스에 %s가 없음: %q", path, index)
+		}
+	}
+}
+
+func TestIsOutputName(t *testing.T) {
+	base := filepath.Join("out", "CODE.md")
+	tests := map[string]bool{
+		filepath.Join("out", "CODE.md"):        true,
+		filepath.Join("out", "CODE1.md"):       true,
+		filepath.Join("out", "CODE12.md"):      true,
+		filepath.Join("out", "CODE.index.md"):  true,
+		filepath.Join("out", "CODE.go"):        false,
+		filepath.Join("out", "CODEX.md"):       false,
+		filepath.Join("out", "CODE.md.bak"):    false,
+		filepath.Join("other", "CODE1.md"):     false,
+		filepath.Join("out", "sub", "CODE.md"): false,
+	}
+	for path, want := range tests {
+		if got := file.IsOutputName(base, path); got != want {
+			t.Errorf("IsOutputName(%q) = %v, want %v", path, got, want)
+		}
+	}
+}
@@ -1,10 +1,15 @@
 package test
 
 import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
 	"os"
 	"path/filepath"
+	"strings"
 	"testing"
 
+	"github.com/kihyun1998/codemd/internal/file"
 	"github.com/kihyun1998/codemd/internal/generator"
 	"github.com/kihyun1998/codemd/internal/parser"
 )
@@ -124,3 +129,719 @@ func TestMarkdownGenerator(t *testing.T) {
 		})
 	}
 }
+
+func TestMarkdownGeneratorStreamingSplit(t *testing.T) {
+	tempDir := t.TempDir()
+	outputPath := filepath.Join(tempDir, "CODE.md")
+
+	// 1MB 제한을 넘는 여러 줄짜리 파일과 작은 파일
+	bigFile := filepath.Join(tempDir, "big.txt")
+	line := strings.Repeat("x", 99) + "\n"
+	if err := os.WriteFile(bigFile, []byte(strings.Repeat(line, 15000)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	smallFile := filepath.Join(tempDir, "small.go")
+	if err := os.WriteFile(smallFile, []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	sectionTemplate := "{{define \"header\"}}# {{.ProjectName}}\n{{end}}" +
+		"{{define \"file\"}}## {{.Path}}\n```\n{{.Content}}```\n{{end}}"
+
+	mg := generator.NewMarkdownGenerator(parser.NewFileParser(), outputPath, 1)
+	if err := mg.SetTemplate(sectionTemplate); err != nil {
+		t.Fatalf("SetTemplate() error = %v", err)
+	}
+	if err := mg.Generate([]string{smallFile, bigFile}); err != nil {
+		t.Fatalf("Generate() error = %v", err)
+	}
+
+	entries, err := os.ReadDir(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parts := 0
+	for _, entry := range entries {
+		name := entry.Name()
+		if strings.HasPrefix(name, ".codemd-part-") {
+			t.Errorf("임시 파일이 남아있음: %s", name)
+		}
+		if strings.HasPrefix(name, "CODE") && name != "CODE.index.md" {
+			parts++
+			info, err := entry.Info()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if info.Size() > 1024*1024 {
+				t.Errorf("%s의 크기가 제한을 초과함: %d", name, info.Size())
+			}
+		}
+	}
+	if parts < 2 {
+		t.Errorf("출력이 분할되지 않음: %d", parts)
+	}
+	if _, err := os.Stat(filepath.Join(tempDir, "CODE.index.md")); err != nil {
+		t.Errorf("인덱스 파일을 찾을 수 없음: %v", err)
+	}
+}
+
+func TestMarkdownGeneratorFence(t *testing.T) {
+	tempDir := t.TempDir()
+	outputPath := filepath.Join(tempDir, "CODE.md")
+
+	tests := []struct {
+		name    string
+		content string
+		fence   string
+	}{
+		{
+			name:    "백틱 없음",
+			content: "package main",
+			fence:   "```",
+		},
+		{
+			name:    "코드 펜스 포함",
+			content: "# README\n```go\nfmt.Println()\n```",
+			fence:   "````",
+		},
+		{
+			name:    "긴 백틱 연속 포함",
+			content: "const s = `````",
+			fence:   "``````",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testFile := filepath.Join(tempDir, "test.md")
+			if err := os.WriteFile(testFile, []byte(tt.content), 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			mg := generator.NewMarkdownGenerator(parser.NewFileParser(), outputPath, 10)
+			if err := mg.SetTemplate("{{range .Files}}{{.Fence}}\n{{.Content}}\n{{.Fence}}\n{{end}}"); err != nil {
+				t.Fatalf("SetTemplate() error = %v", err)
+			}
+			if err := mg.Generate([]string{testFile}); err != nil {
+				t.Fatalf("Generate() error = %v", err)
+			}
+
+			got, err := os.ReadFile(outputPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := file.GeneratedMarker + tt.fence + "\n" + tt.content + "\n" + tt.fence + "\n"
+			if string(got) != want {
+				t.Errorf("Generate() = %q, want %q", got, want)
+			}
+		})
+	}
+}
+
+// 마크다운이 아닌 템플릿은 분할하면 형식이 깨지므로 크기 제한을 넘으면 출력 없이 실패
+func TestMarkdownGeneratorUnsplittableTemplate(t *testing.T) {
+	tempDir := t.TempDir()
+	readme := filepath.Join(tempDir, "README.md")
+	if err := os.WriteFile(readme, []byte(strings.Repeat("```go\nfmt.Println(\"x\")\n```\n", 200)), 0644); err != nil {
+		t.Fatal(err)
+	}
+	tmpl, err := generator.LoadTemplate("xml")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	generate := func(maxTokens int64) (string, error) {
+		outputPath := filepath.Join(t.TempDir(), "CODE.xml")
+		mg := generator.NewMarkdownGenerator(parser.NewFileParser(), outputPath, 10)
+		if err := mg.SetRootDirs([]string{tempDir}); err != nil {
+			t.Fatal(err)
+		}
+		if err := mg.SetTemplate(tmpl); err != nil {
+			t.Fatal(err)
+		}
+		mg.SetMaxTokens(maxTokens)
+		mg.SetSplittable(generator.IsMarkdownTemplate("xml"))
+		err := mg.Generate([]string{readme})
+		entries, _ := os.ReadDir(filepath.Dir(outputPath))
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		return fmt.Sprint(names), err
+	}
+
+	if names, err := generate(800); err == nil || names != "[]" {
+		t.Errorf("Generate(maxtokens=800) = %v, 파일 %s, want 에러와 빈 디렉토리", err, names)
+	}
+	if names, err := generate(100000); err != nil || names != "[CODE.xml]" {
+		t.Errorf("Generate(maxtokens=100000) = %v, 파일 %s, want CODE.xml 하나", err, names)
+	}
+	if !generator.IsMarkdownTemplate("default") || !generator.IsMarkdownTemplate("custom.tmpl") {
+		t.Error("IsMarkdownTemplate() = false for markdown templates")
+	}
+}
+
+func TestBuiltinTemplates(t *testing.T) {
+	for _, name := range generator.BuiltinTemplateNames() {
+		t.Run(name, func(t *testing.T) {
+			tmpl, err := generator.LoadTemplate(name)
+			if err != nil {
+				t.Fatalf("LoadTemplate() error = %v", err)
+			}
+			tp, err := generator.NewTemplateProcessor(tmpl)
+			if err != nil {
+				t.Fatalf("NewTemplateProcessor() error = %v", err)
+			}
+			got, err := tp.Execute(generator.TemplateData{
+				ProjectName: "demo",
+				Files: []generator.FileData{
+					{Path: "main.go", Content: "package main", Language: "go", Fence: "```"},
+				},
+			})
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if !strings.Contains(got, "main.go") || !strings.Contains(got, "package main") {
+				t.Errorf("Execute() = %q, 파일 내용이 없음", got)
+			}
+		})
+	}
+}
+
+func TestLoadTemplate(t *testing.T) {
+	tempDir := t.TempDir()
+
+	customPath := filepath.Join(tempDir, "custom.tmpl")
+	if err := os.WriteFile(customPath, []byte("{{range .Files}}{{.Path}}{{end}}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	got, err := generator.LoadTemplate(customPath)
+	if err != nil {
+		t.Fatalf("LoadTemplate() error = %v", err)
+	}
+	if got != "{{range .Files}}{{.Path}}{{end}}" {
+		t.Errorf("LoadTemplate() = %q", got)
+	}
+
+	if _, err := generator.LoadTemplate(filepath.Join(tempDir, "missing.tmpl")); err == nil {
+		t.Error("존재하지 않는 템플릿에 대해 에러가 발생하지 않음")
+	}
+}
+
+func TestTemplateErrorLine(t *testing.T) {
+	_, err := generator.NewTemplateProcessor("# title\n{{range .Files}}\n{{.Path}\n{{end}}")
+
+	var te *generator.TemplateError
+	if !errors.As(err, &te) {
+		t.Fatalf("TemplateError가 아님: %v", err)
+	}
+	if te.Line != 3 {
+		t.Errorf("Line = %d, want 3", te.Line)
+	}
+	if te.Excerpt != "{{.Path}" {
+		t.Errorf("Excerpt = %q, want %q", te.Excerpt, "{{.Path}")
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	tests := []struct {
+		name     string
+		template string
+		data     generator.TemplateData
+		want     string
+	}{
+		{
+			name:     "파일 헤더",
+			template: "{{range .Files}}{{.Path}} ({{.Lines}} lines, {{humanSize .Size}}){{end}}",
+			data: generator.TemplateData{
+				Files: []generator.FileData{{Path: "main.go", Lines: 120, Size: 3482}},
+			},
+			want: "main.go (120 lines, 3.4 KB)",
+		},
+		{
+			name:     "줄 번호",
+			template: "{{range .Files}}{{lineNumbers .Content}}{{end}}",
+			data: generator.TemplateData{
+				Files: []generator.FileData{{Content: "a\nb\n"}},
+			},
+			want: "1 | a\n2 | b",
+		},
+		{
+			name:     "들여쓰기",
+			template: "{{range .Files}}{{indent 2 .Content}}{{end}}",
+			data: generator.TemplateData{
+				Files: []generator.FileData{{Content: "a\nb"}},
+			},
+			want: "  a\n  b",
+		},
+		{
+			name:     "문자열 함수",
+			template: `{{upper .ProjectName}} {{slugify "Hello, World!"}} {{trimSpace "  x  "}}`,
+			data:     generator.TemplateData{ProjectName: "demo"},
+			want:     "DEMO hello-world x",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tp, err := generator.NewTemplateProcessor(tt.template)
+			if err != nil {
+				t.Fatalf("NewTemplateProcessor() error = %v", err)
+			}
+			got, err := tp.Execute(tt.data)
+			if err != nil {
+				t.Fatalf("Execute() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("Execute() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestMarkdownGeneratorFileMetadata(t *testing.T) {
+	tempDir := t.TempDir()
+	outputPath := filepath.Join(tempDir, "CODE.md")
+	testFile := filepath.Join(tempDir, "main.go")
+	if err := os.WriteFile(testFile, []byte("package main\n\nfunc main() {}"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	mg := generator.NewMarkdownGenerator(parser.NewFileParser(), outputPath, 10)
+	if err := mg.SetTemplate("{{range .Files}}{{.Lines}} {{.Size}} {{.Language}} {{.SHA256}} {{.ModTime.IsZero}} {{.Encoding}}{{end}}"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mg.Generate([]string{testFile}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := file.GeneratedMarker + fmt.Sprintf("3 28 go %x false UTF-8", sha256.Sum256([]byte("package main\n\nfunc main() {}")))
+	if string(got) != want {
+		t.Errorf("Generate() = %q, want %q", got, want)
+	}
+}
+
+func TestMarkdownGeneratorRawSizeAndHash(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string][]byte{
+		"crlf.txt":  []byte("a\r\nb\r\n"),
+		"utf16.txt": {0xFF, 0xFE, 'h', 0, 'i', 0, '\n', 0},
+		"euckr.txt": {0xC7, 0xD1, 0xB1, 0xDB, '\n'}, // "한글"
+	}
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+
+	// 변환된 내용이 아니라 디스크에 있는 바이트의 크기와 해시
+	outputPath := filepath.Join(tempDir, "CODE.md")
+	fp := parser.NewFileParser()
+	fp.SetNormalizeNewlines(true)
+	mg := generator.NewMarkdownGenerator(fp, outputPath, 10)
+	if err := mg.SetTemplate("{{range .Files}}{{.Path}} {{.Size}} {{.SHA256}} {{.Encoding}}\n{{end}}"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mg.Generate(paths); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encodings := map[string]string{"crlf.txt": "UTF-8", "utf16.txt": "UTF-16LE", "euckr.txt": "EUC-KR"}
+	for name, content := range files {
+		want := fmt.Sprintf("%s %d %x %s\n", name, len(content), sha256.Sum256(content), encodings[name])
+		if !strings.Contains(string(got), want) {
+			t.Errorf("Generate() = %q, want line %q", got, want)
+		}
+	}
+}
+
+func TestMarkdownGeneratorBinaryFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	png := append([]byte("\x89PNG\r\n\x1a\n"), make([]byte, 2400*1024)...)
+	files := map[string][]byte{
+		"main.go":      []byte("package main\n"),
+		"logo.png":     png,
+		"data/app.bin": {0x01, 0x00, 0x02},
+	}
+	var paths []string
+	for name, content := range files {
+		path := filepath.Join(tempDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, content, 0644); err != nil {
+			t.Fatal(err)
+		}
+		paths = append(paths, path)
+	}
+
+	tests := []struct {
+		name   string
+		mode   generator.BinaryMode
+		want   []string
+		absent []string
+	}{
+		{
+			name:   "기본값은 구조에만 표시",
+			mode:   generator.BinarySkip,
+			want:   []string{"logo.png (binary, 2.3 MB)", "app.bin (binary, 3 B)", "FILE main.go\n"},
+			absent: []string{"FILE logo.png", "FILE data/app.bin"},
+		},
+		{
+			name: "hex 덤프로 포함",
+			mode: generator.BinaryHex,
+			want: []string{"FILE data/app.bin\ntext hex\n00000000  01 00 02  ", "FILE main.go\ngo \n"},
+		},
+		{
+			name: "base64로 포함",
+			mode: generator.BinaryBase64,
+			want: []string{"FILE data/app.bin\ntext base64\nAQAC\n"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outputPath := filepath.Join(t.TempDir(), "CODE.md")
+			mg := generator.NewMarkdownGenerator(parser.NewFileParser(), outputPath, 100)
+			if err := mg.SetRootDirs([]string{tempDir}); err != nil {
+				t.Fatal(err)
+			}
+			mg.SetBinaryMode(tt.mode)
+			if err := mg.SetTemplate(`{{define "header"}}{{.Structure}}{{end}}{{define "file"}}FILE {{.Path}}
+{{.Language}} {{.Binary}}
+{{if ne .Path "logo.png"}}{{.Content}}
+{{end}}{{end}}`); err != nil {
+				t.Fatal(err)
+			}
+			if err := mg.Generate(paths); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := os.ReadFile(outputPath)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, want := range tt.want {
+				if !strings.Contains(string(got), want) {
+					t.Errorf("Generate() 결과에 %q가 없음:\n%s", want, got)
+				}
+			}
+			for _, absent := range tt.absent {
+				if strings.Contains(string(got), absent) {
+					t.Errorf("Generate() 결과에 %q가 있으면 안 됨:\n%s", absent, got)
+				}
+			}
+		})
+	}
+}
+
+func TestMarkdownGeneratorSkipLargeFiles(t *testing.T) {
+	tempDir := t.TempDir()
+	small := filepath.Join(tempDir, "main.go")
+	large := filepath.Join(tempDir, "fixture.json")
+	if err := os.WriteFile(small, []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(large, []byte(strings.Repeat("{}\n", 1000)), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fp := parser.NewFileParser()
+	fp.SetSizeLimit(parser.SizeLimit{MaxLines: 100, Strategy: parser.TruncateSkip})
+
+	outputPath := filepath.Join(tempDir, "CODE.md")
+	mg := generator.NewMarkdownGenerator(fp, outputPath, 10)
+	if err := mg.SetRootDirs([]string{tempDir}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mg.SetTemplate(`{{define "header"}}{{.Structure}}{{end}}{{define "file"}}FILE {{.Path}}
+{{end}}`); err != nil {
+		t.Fatal(err)
+	}
+	if err := mg.Generate([]string{large, small}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(got), "fixture.json (skipped, 2.9 KB)") || strings.Contains(string(got), "FILE fixture.json") {
+		t.Errorf("Generate() = %s", got)
+	}
+
+	truncations := mg.Truncations()
+	if len(truncations) != 1 || truncations[0].Path != "fixture.json" || truncations[0].Lines != 1000 {
+		t.Errorf("Truncations() = %+v", truncations)
+	}
+}
+
+func TestMarkdownGeneratorTruncatedMetadata(t *testing.T) {
+	tempDir := t.TempDir()
+	var b strings.Builder
+	for i := 0; i < 1000; i++ {
+		fmt.Fprintf(&b, "line %d\n", i)
+	}
+	content := []byte(b.String())
+	path := filepath.Join(tempDir, "big.txt")
+	if err := os.WriteFile(path, content, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	// 잘린 내용이 아니라 원본 파일의 크기, 줄 수, 해시
+	fp := parser.NewFileParser()
+	fp.SetSizeLimit(parser.SizeLimit{MaxLines: 10, Strategy: parser.TruncateHead})
+	outputPath := filepath.Join(tempDir, "CODE.md")
+	mg := generator.NewMarkdownGenerator(fp, outputPath, 10)
+	if err := mg.SetRootDirs([]string{tempDir}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mg.SetTemplate("{{range .Files}}{{.Size}} {{.Lines}} {{.SHA256}} {{.Truncated}}{{end}}"); err != nil {
+		t.Fatal(err)
+	}
+	if err := mg.Generate([]string{path}); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := file.GeneratedMarker + fmt.Sprintf("%d 1000 %x true", len(content), sha256.Sum256(content))
+	if string(got) != want {
+		t.Errorf("Generate() = %q, want %q", got, want)
+	}
+}
+
+func TestMarkdownGeneratorKeepGoing(t *testing.T) {
+	tempDir := t.TempDir()
+	good := filepath.Join(tempDir, "main.go")
+	broken := filepath.Join(tempDir, "broken.go")
+	if err := os.WriteFile(good, []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Symlink(filepath.Join(tempDir, "missing.go"), broken); err != nil {
+		t.Skipf("심볼릭 링크를 만들 수 없음: %v", err)
+	}
+	walkErr := &parser.ParseError{Path: filepath.Join(tempDir, "private"), Err: &os.PathError{Op: "open", Path: "private", Err: os.ErrPermission}}
+
+	newGenerator := func(template string, keepGoing bool) (generator.MarkdownGenerator, string) {
+		outputPath := filepath.Join(t.TempDir(), "CODE.md")
+		mg := generator.NewMarkdownGenerator(parser.NewFileParser(), outputPath, 10)
+		if err := mg.SetRootDirs([]string{tempDir}); err != nil {
+			t.Fatal(err)
+		}
+		if err := mg.SetTemplate(template); err != nil {
+			t.Fatal(err)
+		}
+		mg.SetKeepGoing(keepGoing)
+		mg.SetWalkErrors([]*parser.ParseError{walkErr})
+		return mg, outputPath
+	}
+	sections := `{{define "header"}}{{.Structure}}{{end}}{{define "file"}}FILE {{.Path}}
+{{end}}{{define "footer"}}FOOTER {{len .Skipped}}
+{{end}}`
+
+	t.Run("기본 모드는 실패", func(t *testing.T) {
+		mg, _ := newGenerator(sections, false)
+		var parseErr *parser.ParseError
+		if err := mg.Generate([]string{broken, good}); !errors.As(err, &parseErr) {
+			t.Errorf("Generate() error = %v, want ParseError", err)
+		}
+	})
+
+	t.Run("건너뛴 파일 목록", func(t *testing.T) {
+		mg, outputPath := newGenerator(sections, true)
+		if err := mg.Generate([]string{broken, good}); err != nil {
+			t.Fatal(err)
+		}
+
+		want := []generator.SkippedFile{
+			{Path: "private", Reason: os.ErrPermission.Error()},
+			{Path: "broken.go", Reason: "no such file or directory"},
+		}
+		if got := mg.Skipped(); fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("Skipped() = %+v, want %+v", got, want)
+		}
+
+		got, err := os.ReadFile(outputPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, part := range []string{
+			"broken.go (skipped, no such file or directory)",
+			"FILE main.go\n## Skipped files\n\n- `private`: permission denied\n- `broken.go`: no such file or directory\n\nFOOTER 2\n",
+		} {
+			if !strings.Contains(string(got), part) {
+				t.Errorf("Generate() = %s, want to contain %q", got, part)
+			}
+		}
+		if strings.Contains(string(got), "FILE broken.go") {
+			t.Errorf("Generate() included skipped file: %s", got)
+		}
+	})
+
+	t.Run("skipped 템플릿", func(t *testing.T) {
+		mg, outputPath := newGenerator(sections+`{{define "skipped"}}{{range .Skipped}}SKIP {{.Path}}
+{{end}}{{end}}`, true)
+		if err := mg.Generate([]string{broken, good}); err != nil {
+			t.Fatal(err)
+		}
+		got, err := os.ReadFile(outputPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.HasSuffix(string(got), "FILE main.go\nSKIP private\nSKIP broken.go\nFOOTER 2\n") {
+			t.Errorf("Generate() = %s", got)
+		}
+	})
+}
+
+func TestMarkdownGeneratorSymlinks(t *testing.T) {
+	tempDir := t.TempDir()
+	mainFile := filepath.Join(tempDir, "main.go")
+	if err := os.WriteFile(mainFile, []byte("package main\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, link := range []bool{false, true} {
+		outputPath := filepath.Join(t.TempDir(), "CODE.md")
+		mg := generator.NewMarkdownGenerator(parser.NewFileParser(), outputPath, 10)
+		if err := mg.SetRootDirs([]string{tempDir}); err != nil {
+			t.Fatal(err)
+		}
+		if err := mg.SetTemplate(`{{define "header"}}{{.Structure}}{{end}}{{define "file"}}FILE {{.Path}}
+{{end}}`); err != nil {
+			t.Fatal(err)
+		}
+		mg.SetLinkStructure(link)
+		mg.SetSymlinks([]parser.Symlink{
+			{Path: filepath.Join(tempDir, "lib", "shared"), Target: "../../shared", IsDir: true},
+			{Path: filepath.Join(tempDir, "alias.go"), Target: "main.go"},
+		})
+		if err := mg.Generate([]string{mainFile}); err != nil {
+			t.Fatal(err)
+		}
+
+		got, err := os.ReadFile(outputPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{"alias.go -> main.go", "shared -> ../../shared", "FILE main.go"} {
+			if !strings.Contains(string(got), want) {
+				t.Errorf("Generate(linktree=%t) = %s, want to contain %q", link, got, want)
+			}
+		}
+		if strings.Contains(string(got), "FILE alias.go") {
+			t.Errorf("Generate(linktree=%t) included link content: %s", link, got)
+		}
+	}
+}
+
+func TestMarkdownGeneratorTOC(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, name := range []string{"a.go", "ago", "한글 파일.go"} {
+		if err := os.WriteFile(filepath.Join(tempDir, name), []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	outputPath := filepath.Join(tempDir, "CODE.md")
+	mg := generator.NewMarkdownGenerator(parser.NewFileParser(), outputPath, 10)
+	if err := mg.SetRootDirs([]string{tempDir}); err != nil {
+		t.Fatal(err)
+	}
+	mg.SetLinkStructure(true)
+	if err := mg.SetTemplate(`{{define "header"}}{{.Structure}}{{.TOC}}{{end}}{{define "file"}}{{.Anchor}}
+{{end}}`); err != nil {
+		t.Fatal(err)
+	}
+
+	files := []string{
+		filepath.Join(tempDir, "a.go"),
+		filepath.Join(tempDir, "ago"),
+		filepath.Join(tempDir, "한글 파일.go"),
+	}
+	if err := mg.Generate(files); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wants := []string{
+		"- [a.go](#ago)\n",
+		"- [ago](#ago-1)\n",
+		"- [한글 파일.go](#한글-파일go)\n",
+		"  - [ago](#ago-1)\n",
+		"\nago\nago-1\n한글-파일go\n",
+	}
+	for _, want := range wants {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("출력에 %q가 없음:\n%s", want, got)
+		}
+	}
+}
+
+func TestMarkdownGeneratorMultiRoot(t *testing.T) {
+	tempDir := t.TempDir()
+	serviceDir := filepath.Join(tempDir, "service-a")
+	libDir := filepath.Join(tempDir, "lib-b")
+	files := []string{
+		filepath.Join(serviceDir, "main.go"),
+		filepath.Join(libDir, "util", "util.go"),
+	}
+	for _, path := range files {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("package x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	outputPath := filepath.Join(tempDir, "CODE.md")
+	mg := generator.NewMarkdownGenerator(parser.NewFileParser(), outputPath, 10)
+	if err := mg.SetRootDirs([]string{serviceDir, libDir}); err != nil {
+		t.Fatal(err)
+	}
+	if err := mg.SetTemplate(`{{define "header"}}{{.ProjectName}}
+{{.Structure}}{{end}}{{define "file"}}{{.Path}}
+{{end}}`); err != nil {
+		t.Fatal(err)
+	}
+	if err := mg.Generate(files); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := os.ReadFile(outputPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	wants := []string{
+		"service-a, lib-b\n",
+		"### service-a\n\n```\nservice-a/\n└── main.go\n```\n",
+		"### lib-b\n\n```\nlib-b/\n└── util/\n    └── util.go\n```\n",
+		"service-a/main.go\n",
+		"lib-b/util/util.go\n",
+	}
+	for _, want := range wants {
+		if !strings.Contains(string(got), want) {
+			t.Errorf("출력에 %q가 없음:\n%s", want, got)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package test
+
+import (
+	"testing"
+
+	"github.com/kihyun1998/codemd/internal/generator"
+)
+
+func TestLanguageRegistry(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		content string
+		want    string
+	}{
+		{name: "yml 확장자", path: "config/app.yml", want: "yaml"},
+		{name: "헤더 파일", path: "include/header.h", want: "c"},
+		{name: "kotlin", path: "Main.kt", want: "kotlin"},
+		{name: "tsx", path: "App.tsx", want: "tsx"},
+		{name: "rust", path: "src/lib.rs", want: "rust"},
+		{name: "대문자 확장자", path: "Main.GO", want: "go"},
+		{name: "Dockerfile", path: "build/Dockerfile", want: "dockerfile"},
+		{name: "Dockerfile 변형", path: "Dockerfile.dev", want: "dockerfile"},
+		{name: "Makefile", path: "Makefile", want: "makefile"},
+		{name: "shebang env", path: "scripts/deploy", content: "#!/usr/bin/env python3\nprint()", want: "python"},
+		{name: "shebang 직접 경로", path: "run", content: "#!/bin/bash\necho hi", want: "bash"},
+		{name: "알 수 없는 확장자", path: "data.xyz", want: "xyz"},
+		{name: "알 수 없는 파일", path: "LICENSE", want: ""},
+	}
+
+	registry := generator.NewLanguageRegistry()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := registry.Detect(tt.path, tt.content); got != tt.want {
+				t.Errorf("Detect(%q) = %q, want %q", tt.path, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLanguageRegistryOverride(t *testing.T) {
+	registry := generator.NewLanguageRegistry()
+	registry.SetOverride("h", "cpp")
+	registry.SetOverride(".tpl", "html")
+	registry.SetOverride("Jenkinsfile", "groovy-pipeline")
+
+	tests := map[string]string{
+		"include/header.h": "cpp",
+		"page.tpl":         "html",
+		"Jenkinsfile":      "groovy-pipeline",
+		"main.go":          "go",
+	}
+	for path, want := range tests {
+		if got := registry.Detect(path, ""); got != want {
+			t.Errorf("Detect(%q) = %q, want %q", path, got, want)
+		}
+	}
+}
@@ -0,0 +1,247 @@
+package test
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sync"
+	"testing"
+
+	"github.com/kihyun1998/codemd/internal/generator"
+	"github.com/kihyun1998/codemd/internal/parser"
+)
+
+// benchmarkFileCount는 벤치마크용 합성 트리의 파일 수 (100개 디렉토리 × 10개 하위 디렉토리 × 100개 파일)
+const benchmarkFileCount = 100000
+
+var (
+	benchmarkTreeOnce sync.Once
+	benchmarkTreeDir  string
+	benchmarkTreeErr  error
+)
+
+func TestMain(m *testing.M) {
+	code := m.Run()
+	if benchmarkTreeDir != "" {
+		os.RemoveAll(benchmarkTreeDir)
+	}
+	os.Exit(code)
+}
+
+// benchmarkTree는 벤치마크 전체에서 함께 쓰는 합성 트리를 한 번만 생성
+func benchmarkTree(b *testing.B) string {
+	b.Helper()
+	benchmarkTreeOnce.Do(func() {
+		if benchmarkTreeDir, benchmarkTreeErr = os.MkdirTemp("", "codemd-bench-"); benchmarkTreeErr != nil {
+			return
+		}
+		for i := 0; i < benchmarkFileCount; i++ {
+			dir := filepath.Join(benchmarkTreeDir, fmt.Sprintf("pkg%02d", i/1000), fmt.Sprintf("sub%d", i/100%10))
+			if i%100 == 0 {
+				if benchmarkTreeErr = os.MkdirAll(dir, 0755); benchmarkTreeErr != nil {
+					return
+				}
+			}
+			content := fmt.Sprintf("package sub\n\n// File%d는 벤치마크용 함수\nfunc File%d() int {\n\treturn %d\n}\n", i, i, i)
+			path := filepath.Join(dir, fmt.Sprintf("file%03d.go", i%100))
+			if benchmarkTreeErr = os.WriteFile(path, []byte(content), 0644); benchmarkTreeErr != nil {
+				return
+			}
+		}
+	})
+	if benchmarkTreeErr != nil {
+		b.Fatal(benchmarkTreeErr)
+	}
+	return benchmarkTreeDir
+}
+
+// createJobsTree는 이름이 서로의 접두사인 파일과 디렉토리, 무시 규칙이 섞인 트리를 생성
+func createJobsTree(t *testing.T) string {
+	t.Helper()
+	tempDir := t.TempDir()
+	files := map[string]string{
+		".codeignore":        "*.log\nbuild/\n!keep.log\n",
+		"a.go":               "package a\n",
+		"a/x.go":             "package x\n",
+		"a/b/y.go":           "package y\n",
+		"a-b/z.go":           "package z\n",
+		"a.b/w.go":           "package w\n",
+		"build/out.go":       "package out\n",
+		"logs/debug.log":     "debug\n",
+		"logs/keep.log":      "keep\n",
+		".hidden/secret.go":  "package secret\n",
+		"vendor/lib/lib.go":  "package lib\n",
+		"cmd/app/main.go":    "package main\n",
+		"cmd/app/main_a.go":  "package main\n",
+		"cmd/app.go":         "package cmd\n",
+		"internal/deep/1.go": "package deep\n",
+	}
+	for i := 0; i < 50; i++ {
+		files[fmt.Sprintf("pkg%d/sub%d/file%d.go", i%7, i%3, i)] = fmt.Sprintf("package p%d\n", i)
+	}
+	for name, content := range files {
+		path := filepath.Join(tempDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return tempDir
+}
+
+func TestDirectoryParserJobs(t *testing.T) {
+	tempDir := createJobsTree(t)
+
+	parse := func(jobs int) []string {
+		p := parser.NewDirectoryParser([]string{"vendor"}, false, true)
+		p.SetJobs(jobs)
+		files, err := p.Parse(tempDir)
+		if err != nil {
+			t.Fatalf("Parse(jobs=%d) error = %v", jobs, err)
+		}
+		return files
+	}
+
+	want := parse(1)
+	if len(want) == 0 {
+		t.Fatal("Parse(jobs=1) found no files")
+	}
+	for _, unwanted := range []string{"build", "debug.log", "secret.go", "lib.go"} {
+		for _, path := range want {
+			if filepath.Base(path) == unwanted || filepath.Base(filepath.Dir(path)) == unwanted {
+				t.Errorf("Parse() included %s", path)
+			}
+		}
+	}
+
+	// 작업자 수와 관계없이 같은 파일이 같은 순서로 나와야 함
+	for _, jobs := range []int{2, 4, 16} {
+		for run := 0; run < 5; run++ {
+			if got := parse(jobs); !slices.Equal(got, want) {
+				t.Fatalf("Parse(jobs=%d) =\n%v\nwant\n%v", jobs, got, want)
+			}
+		}
+	}
+}
+
+func TestDirectoryParserJobsError(t *testing.T) {
+	p := parser.NewDirectoryParser(nil, false, false)
+	p.SetJobs(4)
+	if _, err := p.Parse(filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Error("Parse() of missing root should fail")
+	}
+}
+
+func TestMarkdownGeneratorJobs(t *testing.T) {
+	tempDir := createJobsTree(t)
+	p := parser.NewDirectoryParser(nil, false, true)
+	files, err := p.Parse(tempDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	files = append(files, filepath.Join(tempDir, "image.png"))
+	if err := os.WriteFile(files[len(files)-1], []byte("\x89PNG\r\n\x1a\n\x00\x01"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	generate := func(jobs int, template string) string {
+		outputPath := filepath.Join(t.TempDir(), "CODE.md")
+		fp := parser.NewFileParser()
+		fp.SetSizeLimit(parser.SizeLimit{MaxLines: 1, Strategy: parser.TruncateHead})
+		mg := generator.NewMarkdownGenerator(fp, outputPath, 10)
+		if err := mg.SetRootDirs([]string{tempDir}); err != nil {
+			t.Fatal(err)
+		}
+		if err := mg.SetTemplate(template); err != nil {
+			t.Fatal(err)
+		}
+		mg.SetBinaryMode(generator.BinaryHex)
+		mg.SetJobs(jobs)
+		if err := mg.Generate(files); err != nil {
+			t.Fatalf("Generate(jobs=%d) error = %v", jobs, err)
+		}
+
+		var truncated []string
+		for _, truncation := range mg.Truncations() {
+			truncated = append(truncated, truncation.Path)
+		}
+		content, err := os.ReadFile(outputPath)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return fmt.Sprint(truncated) + "\n" + string(content)
+	}
+
+	defaultTemplate, err := generator.LoadTemplate(generator.DefaultTemplateName)
+	if err != nil {
+		t.Fatal(err)
+	}
+	templates := map[string]string{
+		"섹션 템플릿": defaultTemplate,
+		"단일 템플릿": "{{range .Files}}## {{.Path}}\n{{.Content}}\n{{end}}",
+	}
+	for name, template := range templates {
+		t.Run(name, func(t *testing.T) {
+			want := generate(1, template)
+			for _, jobs := range []int{2, 8} {
+				if got := generate(jobs, template); got != want {
+					t.Errorf("Generate(jobs=%d) output differs from jobs=1\ngot:\n%s\nwant:\n%s", jobs, got, want)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkDirectoryParserParse(b *testing.B) {
+	root := benchmarkTree(b)
+	for _, jobs := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("jobs=%d", jobs), func(b *testing.B) {
+			p := parser.NewDirectoryParser(nil, false, false)
+			p.SetJobs(jobs)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				files, err := p.Parse(root)
+				if err != nil {
+					b.Fatal(err)
+				}
+				if len(files) != benchmarkFileCount {
+					b.Fatalf("Parse() = %d files, want %d", len(files), benchmarkFileCount)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkMarkdownGeneratorGenerate(b *testing.B) {
+	root := benchmarkTree(b)
+	files, err := parser.NewDirectoryParser(nil, false, false).Parse(root)
+	if err != nil {
+		b.Fatal(err)
+	}
+	template, err := generator.LoadTemplate(generator.DefaultTemplateName)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for _, jobs := range []int{1, 4, 16} {
+		b.Run(fmt.Sprintf("jobs=%d", jobs), func(b *testing.B) {
+			mg := generator.NewMarkdownGenerator(parser.NewFileParser(), filepath.Join(b.TempDir(), "CODE.md"), 1024)
+			if err := mg.SetRootDirs([]string{root}); err != nil {
+				b.Fatal(err)
+			}
+			if err := mg.SetTemplate(template); err != nil {
+				b.Fatal(err)
+			}
+			mg.SetJobs(jobs)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if err := mg.Generate(files); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
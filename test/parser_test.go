@@ -1,10 +1,16 @@
 package test
 
 import (
+	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
+	"runtime"
+	"slices"
+	"strings"
 	"testing"
 
+	"github.com/kihyun1998/codemd/internal/file"
 	"github.com/kihyun1998/codemd/internal/parser"
 )
 
@@ -65,6 +71,119 @@ func TestDirectoryParser(t *testing.T) {
 	}
 }
 
+func TestDirectoryParserGitIgnore(t *testing.T) {
+	tempDir := t.TempDir()
+	t.Setenv("HOME", tempDir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(tempDir, "xdg"))
+
+	files := map[string]string{
+		".gitignore":      "build/\n",
+		".codeignore":     "*.txt\n",
+		"main.go":         "",
+		"notes.txt":       "",
+		"build/output.go": "",
+		"src/.gitignore":  "*.gen.go\n",
+		"src/app.go":      "",
+		"src/app.gen.go":  "",
+	}
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p := parser.NewDirectoryParser(nil, false, true)
+	p.SetGitIgnore(true)
+
+	got, err := p.Parse(tempDir)
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	want := []string{
+		filepath.Join(tempDir, "main.go"),
+		filepath.Join(tempDir, "src", "app.go"),
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Parse() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("Parse()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestDirectoryParserCodeIgnoreLegacy(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		".codeignore": "*.log\n!logs/\n",
+		"app.log":     "",
+		"logs/a.log":  "",
+		"logs/a.txt":  "",
+	}
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		legacy bool
+		want   []string
+	}{
+		{false, []string{"logs/a.txt"}},              // git과 같이 *.log로 무시된 파일은 그대로 무시
+		{true, []string{"logs/a.log", "logs/a.txt"}}, // 이전 동작: !logs/가 안의 파일도 다시 포함
+	}
+	for _, tt := range tests {
+		p := parser.NewDirectoryParser(nil, false, true)
+		p.SetCodeIgnoreLegacy(tt.legacy)
+		got, err := p.Parse(tempDir)
+		if err != nil {
+			t.Fatalf("Parse() error = %v", err)
+		}
+		var want []string
+		for _, name := range tt.want {
+			want = append(want, filepath.Join(tempDir, filepath.FromSlash(name)))
+		}
+		if !slices.Equal(got, want) {
+			t.Errorf("Parse(legacy=%v) = %v, want %v", tt.legacy, got, want)
+		}
+	}
+}
+
+func TestDirectoryParserRelativeRoot(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.WriteFile(filepath.Join(tempDir, "main.go"), []byte("test"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Chdir(tempDir); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chdir(wd)
+
+	got, err := parser.NewDirectoryParser(nil, false, false).Parse(".")
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	if len(got) != 1 || got[0] != "main.go" {
+		t.Errorf("Parse() = %v, want [main.go]", got)
+	}
+}
+
 func TestGetFilesByTypes(t *testing.T) {
 	p := parser.NewDirectoryParser(nil, false, true)
 	files := []string{
@@ -79,6 +198,443 @@ func TestGetFilesByTypes(t *testing.T) {
 	}
 }
 
+func TestDirectoryParserExcludePatterns(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		".codeignore":              "*.bak\n!internal/legacy/keep.bak\n",
+		"main.go":                  "",
+		"vendor/a.go":              "",
+		"lib/vendor/b.go":          "",
+		"internal/legacy/c.go":     "",
+		"internal/legacy/keep.bak": "",
+		"internal/app/d.go":        "",
+		"legacy/e.go":              "",
+		"web/app.min.js":           "",
+		"web/app.js":               "",
+		"web/build":                "",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		excludes []string
+		want     []string
+	}{
+		{
+			name:     "이름은 어느 깊이에서든 매칭",
+			excludes: []string{"vendor"},
+			want:     []string{"internal/app/d.go", "internal/legacy/c.go", "internal/legacy/keep.bak", "legacy/e.go", "main.go", "web/app.js", "web/app.min.js", "web/build"},
+		},
+		{
+			name:     "루트 기준 경로와 파일 glob",
+			excludes: []string{"internal/legacy", "./lib/vendor", "*.min.js"},
+			want:     []string{"internal/app/d.go", "legacy/e.go", "main.go", "vendor/a.go", "web/app.js", "web/build"},
+		},
+		{
+			name:     "끝이 /이면 디렉토리만",
+			excludes: []string{"build/", "web/*.js"},
+			want:     []string{"internal/app/d.go", "internal/legacy/c.go", "internal/legacy/keep.bak", "legacy/e.go", "lib/vendor/b.go", "main.go", "vendor/a.go", "web/build"},
+		},
+		{
+			name:     ".codeignore 부정 패턴보다 우선",
+			excludes: []string{"keep.bak"},
+			want:     []string{"internal/app/d.go", "internal/legacy/c.go", "legacy/e.go", "lib/vendor/b.go", "main.go", "vendor/a.go", "web/app.js", "web/app.min.js", "web/build"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser.NewDirectoryParser(tt.excludes, false, true)
+			got, err := p.Parse(root)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var names []string
+			for _, path := range got {
+				rel, _ := filepath.Rel(root, path)
+				names = append(names, filepath.ToSlash(rel))
+			}
+			if strings.Join(names, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("Parse() = %v, want %v", names, tt.want)
+			}
+		})
+	}
+
+	// 부정 패턴으로 포함된 파일도 -exclude 단계에서 제외되고 그 항목이 규칙으로 표시됨
+	p := parser.NewDirectoryParser([]string{"internal/legacy/keep.bak"}, false, true)
+	decision, err := p.Explain(root, filepath.Join(root, "internal", "legacy", "keep.bak"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decision.Included || decision.Stage != parser.StageExclude || decision.Rule != "internal/legacy/keep.bak" {
+		t.Errorf("Explain() = %+v, want exclude 단계에서 제외", decision)
+	}
+}
+
+func TestDirectoryParserOutputFiles(t *testing.T) {
+	root := t.TempDir()
+	files := map[string]string{
+		"main.go":       "package main\n",
+		"CODE.md":       "이전 출력",
+		"CODE2.md":      "이전 출력",
+		"CODE.index.md": "이전 출력",
+		"docs/old.md":   file.GeneratedMarker + "# 다른 설정으로 만든 출력\n",
+		"docs/old.txt":  file.GeneratedMarker + "# .md가 아니면 생성 표시를 확인하지 않음\n",
+		"README.md":     "# README\n",
+	}
+	for name, content := range files {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	p := parser.NewDirectoryParser(nil, false, false)
+	p.SetOutputPath(filepath.Join(root, "CODE.md"))
+	got, err := p.Parse(root)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var names []string
+	for _, path := range got {
+		rel, _ := filepath.Rel(root, path)
+		names = append(names, filepath.ToSlash(rel))
+	}
+	if want := "README.md,docs/old.txt,main.go"; strings.Join(names, ",") != want {
+		t.Errorf("Parse() = %v, want %s", names, want)
+	}
+
+	decision, err := p.Explain(root, filepath.Join(root, "docs", "old.md"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decision.Included || decision.Stage != parser.StageOutput {
+		t.Errorf("Explain() = %+v, want output 단계에서 제외", decision)
+	}
+
+	// 출력 파일과 확장자가 같으면 .md가 아니어도 생성 표시 확인
+	p.SetOutputPath(filepath.Join(root, "CODE.txt"))
+	decision, err = p.Explain(root, filepath.Join(root, "docs", "old.txt"), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if decision.Included || decision.Stage != parser.StageOutput {
+		t.Errorf("Explain() = %+v, want output 단계에서 제외", decision)
+	}
+}
+
+func TestDirectoryParserSelectFiles(t *testing.T) {
+	root := t.TempDir()
+	names := []string{
+		"main.GO", "Dockerfile", "Makefile", "photo.JPG",
+		"web/types.d.ts", "web/app.ts",
+		"cmd/app/main.go", "cmd/app/main_test.go", "internal/x.go",
+	}
+	var files []string
+	for _, name := range names {
+		path := filepath.Join(root, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("x"), 0644); err != nil {
+			t.Fatal(err)
+		}
+		files = append(files, path)
+	}
+
+	tests := []struct {
+		name     string
+		types    []string
+		includes []string
+		want     []string
+	}{
+		{"조건 없음", []string{""}, nil, names},
+		{"대소문자 구분 없는 확장자", []string{"go", "jpg"}, nil, []string{"main.GO", "photo.JPG", "cmd/app/main.go", "cmd/app/main_test.go", "internal/x.go"}},
+		{"여러 점 확장자와 파일 이름", []string{"d.ts", "Dockerfile"}, nil, []string{"Dockerfile", "web/types.d.ts"}},
+		{"포함 패턴", []string{""}, []string{"cmd/**/*.go", "Makefile"}, []string{"Makefile", "cmd/app/main.go", "cmd/app/main_test.go"}},
+		{"타입과 포함 패턴의 합집합", []string{"ts"}, []string{"*_test.go"}, []string{"web/types.d.ts", "web/app.ts", "cmd/app/main_test.go"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser.NewDirectoryParser(nil, false, false)
+			p.SetIncludePatterns(tt.includes)
+
+			var got []string
+			for _, file := range p.SelectFiles(root, files, tt.types) {
+				rel, _ := filepath.Rel(root, file)
+				got = append(got, filepath.ToSlash(rel))
+			}
+			if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+				t.Errorf("SelectFiles() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDirectoryParserKeepGoing(t *testing.T) {
+	if runtime.GOOS == "windows" || os.Geteuid() == 0 {
+		t.Skip("권한으로 디렉토리 읽기를 막을 수 없는 환경")
+	}
+
+	tempDir := t.TempDir()
+	private := filepath.Join(tempDir, "private")
+	for _, name := range []string{"a.go", "private/secret.go", "z.go"} {
+		path := filepath.Join(tempDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("package x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := os.Chmod(private, 0); err != nil {
+		t.Fatal(err)
+	}
+	defer os.Chmod(private, 0755)
+
+	for _, jobs := range []int{1, 4} {
+		p := parser.NewDirectoryParser(nil, false, false)
+		p.SetJobs(jobs)
+		if _, err := p.Parse(tempDir); err == nil {
+			t.Errorf("Parse(jobs=%d) without keep-going should fail", jobs)
+		}
+
+		p.SetKeepGoing(true)
+		files, err := p.Parse(tempDir)
+		if err != nil {
+			t.Fatalf("Parse(jobs=%d) error = %v", jobs, err)
+		}
+		want := []string{filepath.Join(tempDir, "a.go"), filepath.Join(tempDir, "z.go")}
+		if fmt.Sprint(files) != fmt.Sprint(want) {
+			t.Errorf("Parse(jobs=%d) = %v, want %v", jobs, files, want)
+		}
+		if skipped := p.Skipped(); len(skipped) != 1 || skipped[0].Path != private {
+			t.Errorf("Skipped(jobs=%d) = %v", jobs, skipped)
+		}
+	}
+}
+
+func TestDirectoryParserSymlinks(t *testing.T) {
+	tempDir := t.TempDir()
+	root := filepath.Join(tempDir, "src")
+	for _, name := range []string{"src/lib/lib.go", "shared/s.go"} {
+		path := filepath.Join(tempDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("package x\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	links := map[string]string{
+		"src/shared":   "../shared",  // 루트 밖의 디렉토리
+		"src/lib/up":   "..",         // 루트로 돌아가는 순환
+		"src/lib/self": ".",          // 자기 자신으로 돌아가는 순환
+		"src/alias.go": "lib/lib.go", // 파일 링크
+		"src/gone.go":  "missing.go", // 깨진 링크
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(tempDir, filepath.FromSlash(name))); err != nil {
+			t.Skipf("심볼릭 링크를 만들 수 없음: %v", err)
+		}
+	}
+
+	rel := func(paths []string) []string {
+		var result []string
+		for _, path := range paths {
+			relPath, _ := filepath.Rel(root, path)
+			result = append(result, filepath.ToSlash(relPath))
+		}
+		return result
+	}
+	linkNames := func(links []parser.Symlink) []string {
+		var result []string
+		for _, link := range links {
+			relPath, _ := filepath.Rel(root, link.Path)
+			result = append(result, fmt.Sprintf("%s -> %s (dir=%t)", filepath.ToSlash(relPath), link.Target, link.IsDir))
+		}
+		return result
+	}
+
+	tests := []struct {
+		mode      parser.SymlinkMode
+		wantFiles []string
+		wantLinks []string
+	}{
+		{
+			mode:      parser.SymlinkSkip,
+			wantFiles: []string{"lib/lib.go"},
+		},
+		{
+			mode:      parser.SymlinkFollowFiles,
+			wantFiles: []string{"alias.go", "gone.go", "lib/lib.go"},
+			wantLinks: []string{
+				"lib/self -> . (dir=true)",
+				"lib/up -> .. (dir=true)",
+				"shared -> ../shared (dir=true)",
+			},
+		},
+		{
+			mode:      parser.SymlinkFollow,
+			wantFiles: []string{"alias.go", "gone.go", "lib/lib.go", "shared/s.go"},
+			wantLinks: []string{"lib/self -> . (dir=true)", "lib/up -> .. (dir=true)"},
+		},
+		{
+			mode:      parser.SymlinkRecord,
+			wantFiles: []string{"lib/lib.go"},
+			wantLinks: []string{
+				"alias.go -> lib/lib.go (dir=false)",
+				"gone.go -> missing.go (dir=false)",
+				"lib/self -> . (dir=true)",
+				"lib/up -> .. (dir=true)",
+				"shared -> ../shared (dir=true)",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		for _, jobs := range []int{1, 4} {
+			t.Run(fmt.Sprintf("%s/jobs=%d", tt.mode, jobs), func(t *testing.T) {
+				p := parser.NewDirectoryParser(nil, false, false)
+				p.SetSymlinkMode(tt.mode)
+				p.SetJobs(jobs)
+				files, err := p.Parse(root)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if got := rel(files); fmt.Sprint(got) != fmt.Sprint(tt.wantFiles) {
+					t.Errorf("Parse() = %v, want %v", got, tt.wantFiles)
+				}
+				if got := linkNames(p.Links()); fmt.Sprint(got) != fmt.Sprint(tt.wantLinks) {
+					t.Errorf("Links() = %v, want %v", got, tt.wantLinks)
+				}
+			})
+		}
+	}
+
+	t.Run("SelectLinks", func(t *testing.T) {
+		p := parser.NewDirectoryParser(nil, false, false)
+		p.SetSymlinkMode(parser.SymlinkRecord)
+		if _, err := p.Parse(root); err != nil {
+			t.Fatal(err)
+		}
+		got := linkNames(p.SelectLinks(root, p.Links(), []string{"md"}))
+		want := []string{"lib/self -> . (dir=true)", "lib/up -> .. (dir=true)", "shared -> ../shared (dir=true)"}
+		if fmt.Sprint(got) != fmt.Sprint(want) {
+			t.Errorf("SelectLinks() = %v, want %v", got, want)
+		}
+	})
+}
+
+// follow 방식에서 같은 디렉토리를 가리키는 경로가 여럿이면 내용은 한 번만 나오고 나머지는 링크로 기록
+func TestDirectoryParserSymlinkFollowOnce(t *testing.T) {
+	tempDir := t.TempDir()
+	root := filepath.Join(tempDir, "root")
+	for _, name := range []string{"root/other/a.txt", "root/src/b/b.txt", "ext/e.txt"} {
+		path := filepath.Join(tempDir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(name+"\n"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	links := map[string]string{
+		"root/src/link":    "../other", // 루트 안의 일반 디렉토리
+		"root/src/b/inner": "../link",  // 링크를 가리키는 링크
+		"root/x":           "../ext",   // 루트 밖의 디렉토리
+		"root/y":           "../ext",   // 같은 디렉토리를 가리키는 두 번째 링크
+	}
+	for name, target := range links {
+		if err := os.Symlink(target, filepath.Join(tempDir, filepath.FromSlash(name))); err != nil {
+			t.Skipf("심볼릭 링크를 만들 수 없음: %v", err)
+		}
+	}
+
+	wantFiles := []string{"other/a.txt", "src/b/b.txt", "x/e.txt"}
+	wantLinks := []string{"src/b/inner -> ../link", "src/link -> ../other", "y -> ../ext"}
+	for _, jobs := range []int{1, 4} {
+		p := parser.NewDirectoryParser(nil, false, false)
+		p.SetSymlinkMode(parser.SymlinkFollow)
+		p.SetJobs(jobs)
+		files, err := p.Parse(root)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var gotFiles, gotLinks []string
+		for _, path := range files {
+			relPath, _ := filepath.Rel(root, path)
+			gotFiles = append(gotFiles, filepath.ToSlash(relPath))
+		}
+		for _, link := range p.Links() {
+			relPath, _ := filepath.Rel(root, link.Path)
+			gotLinks = append(gotLinks, filepath.ToSlash(relPath)+" -> "+link.Target)
+		}
+		if fmt.Sprint(gotFiles) != fmt.Sprint(wantFiles) {
+			t.Errorf("Parse(jobs=%d) = %v, want %v", jobs, gotFiles, wantFiles)
+		}
+		if fmt.Sprint(gotLinks) != fmt.Sprint(wantLinks) {
+			t.Errorf("Links(jobs=%d) = %v, want %v", jobs, gotLinks, wantLinks)
+		}
+	}
+
+	// Explain도 Parse와 같은 순서로 따라가서 한 번만 포함
+	explains := []struct {
+		path     string
+		included bool
+		stage    parser.FilterStage
+	}{
+		{"other/a.txt", true, ""},
+		{"src/link", true, parser.StageSymlink},
+		{"src/link/a.txt", false, parser.StageSymlink},
+		{"src/b/inner/a.txt", false, parser.StageSymlink},
+		{"x/e.txt", true, ""},
+		{"y/e.txt", false, parser.StageSymlink},
+	}
+	p := parser.NewDirectoryParser(nil, false, false)
+	p.SetSymlinkMode(parser.SymlinkFollow)
+	for _, tt := range explains {
+		got, err := p.Explain(root, filepath.Join(root, filepath.FromSlash(tt.path)), nil)
+		if err != nil {
+			t.Fatalf("Explain(%s) error = %v", tt.path, err)
+		}
+		if got.Included != tt.included || got.Stage != tt.stage {
+			t.Errorf("Explain(%s) = %+v, want included=%v stage=%q", tt.path, got, tt.included, tt.stage)
+		}
+	}
+}
+
+func TestParseSymlinkMode(t *testing.T) {
+	for value, want := range map[string]parser.SymlinkMode{
+		"":               parser.SymlinkFollowFiles,
+		"follow-files":   parser.SymlinkFollowFiles,
+		"skip":           parser.SymlinkSkip,
+		"Follow":         parser.SymlinkFollow,
+		"record-as-link": parser.SymlinkRecord,
+	} {
+		if got, err := parser.ParseSymlinkMode(value); err != nil || got != want {
+			t.Errorf("ParseSymlinkMode(%q) = %q, %v, want %q", value, got, err, want)
+		}
+	}
+	if _, err := parser.ParseSymlinkMode("hardlink"); err == nil {
+		t.Error("ParseSymlinkMode(\"hardlink\") should fail")
+	}
+}
+
 func TestFileParser(t *testing.T) {
 	// 임시 디렉토리 생성
 	tempDir := t.TempDir()
@@ -129,3 +685,406 @@ func TestFileParser(t *testing.T) {
 		})
 	}
 }
+
+func TestIsBinary(t *testing.T) {
+	tests := []struct {
+		name string
+		data []byte
+		want bool
+	}{
+		{"빈 파일", nil, false},
+		{"소스 코드", []byte("package main\n\nfunc main() {}\n"), false},
+		{"한글 UTF-8", []byte("// 테스트 컨텐츠\n"), false},
+		{"NUL 바이트", []byte("abc\x00def"), true},
+		{"PNG", []byte("\x89PNG\r\n\x1a\n"), true},
+		{"SQLite", []byte("SQLite format 3\x00..."), true},
+		{"ELF", []byte("\x7fELF\x02\x01\x01"), true},
+		{"잘못된 UTF-8이 많음", []byte("\x80\x81\x82\x83 ab"), true},
+		{"UTF-16 BOM", []byte("\xff\xfea\x00b\x00"), false},
+		{"잘린 마지막 문자", []byte("가나다\xea\xb0"), false},
+		{"EUC-KR", []byte("// \xc7\xd1\xb1\xdb \xc1\xd6\xbc\xae\n"), false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parser.IsBinary(tt.data); got != tt.want {
+				t.Errorf("IsBinary(%q) = %v, want %v", tt.data, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeText(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         []byte
+		want         string
+		wantEncoding string
+	}{
+		{"UTF-8", []byte("한글 주석"), "한글 주석", parser.EncodingUTF8},
+		{"UTF-8 BOM", []byte("\xef\xbb\xbf한글"), "한글", parser.EncodingUTF8BOM},
+		{"UTF-16LE BOM", []byte("\xff\xfe\x00\xacA\x00"), "가A", parser.EncodingUTF16LE},
+		{"UTF-16BE BOM", []byte("\xfe\xff\xac\x00\x00A"), "가A", parser.EncodingUTF16BE},
+		{"EUC-KR", []byte("\xc7\xd1\xb1\xdb \xc1\xd6\xbc\xae"), "한글 주석", parser.EncodingEUCKR},
+		{"CP949 확장 문자", []byte("\x8cc\xb9\xe6\xb0\xa2\xc7\xcf"), "똠방각하", parser.EncodingEUCKR},
+		{"Windows-1252", []byte("caf\xe9!"), "café!", parser.EncodingWindows1252},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, encoding := parser.DecodeText(tt.data)
+			if got != tt.want || encoding != tt.wantEncoding {
+				t.Errorf("DecodeText() = %q, %q, want %q, %q", got, encoding, tt.want, tt.wantEncoding)
+			}
+		})
+	}
+}
+
+func TestFileParserNormalizeNewlines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "legacy.c")
+	if err := os.WriteFile(path, []byte("/* \xc7\xd1\xb1\xdb */\r\nint a;\r\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fp := parser.NewFileParser()
+	text, err := fp.ReadText(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text.Content != "/* 한글 */\r\nint a;\r\n" || text.Encoding != parser.EncodingEUCKR {
+		t.Errorf("ReadText() = %q, %q", text.Content, text.Encoding)
+	}
+
+	fp.SetNormalizeNewlines(true)
+	got, err := fp.ReadContent(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "/* 한글 */\nint a;\n"; got != want {
+		t.Errorf("ReadContent() = %q, want %q", got, want)
+	}
+}
+
+func TestParseSizeLimit(t *testing.T) {
+	tests := []struct {
+		value    string
+		strategy string
+		want     parser.SizeLimit
+		wantErr  bool
+	}{
+		{"", "", parser.SizeLimit{Strategy: parser.TruncateHeadTail}, false},
+		{"512KB", "head", parser.SizeLimit{MaxBytes: 512 * 1024, Strategy: parser.TruncateHead}, false},
+		{"1.5mb,2000lines", "skip", parser.SizeLimit{MaxBytes: 1536 * 1024, MaxLines: 2000, Strategy: parser.TruncateSkip}, false},
+		{"100", "", parser.SizeLimit{}, true},
+		{"0lines", "", parser.SizeLimit{}, true},
+		{"1MB", "tail", parser.SizeLimit{}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.value+"/"+tt.strategy, func(t *testing.T) {
+			got, err := parser.ParseSizeLimit(tt.value, tt.strategy)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSizeLimit() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseSizeLimit() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFileParserSizeLimit(t *testing.T) {
+	var sb strings.Builder
+	for i := 1; i <= 10; i++ {
+		sb.WriteString(fmt.Sprintf("line %d\n", i))
+	}
+	path := filepath.Join(t.TempDir(), "fixture.txt")
+	if err := os.WriteFile(path, []byte(sb.String()), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		limit       parser.SizeLimit
+		want        string
+		wantOmitted int
+	}{
+		{
+			name:  "제한 이내",
+			limit: parser.SizeLimit{MaxLines: 10, Strategy: parser.TruncateHead},
+			want:  sb.String(),
+		},
+		{
+			name:        "앞부분 유지",
+			limit:       parser.SizeLimit{MaxLines: 2, Strategy: parser.TruncateHead},
+			want:        "line 1\nline 2\n... (8 lines omitted) ...",
+			wantOmitted: 8,
+		},
+		{
+			name:        "앞뒤 유지",
+			limit:       parser.SizeLimit{MaxLines: 3, Strategy: parser.TruncateHeadTail},
+			want:        "line 1\n... (7 lines omitted) ...\nline 9\nline 10\n",
+			wantOmitted: 7,
+		},
+		{
+			name:        "바이트 기준 앞부분 유지",
+			limit:       parser.SizeLimit{MaxBytes: 20, Strategy: parser.TruncateHead},
+			want:        "line 1\nline 2\n... (8 lines omitted) ...",
+			wantOmitted: 8,
+		},
+		{
+			name:        "요약",
+			limit:       parser.SizeLimit{MaxLines: 5, Strategy: parser.TruncateSummary},
+			want:        "(10 lines omitted)",
+			wantOmitted: 10,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fp := parser.NewFileParser()
+			fp.SetSizeLimit(tt.limit)
+			text, err := fp.ReadText(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if text.Content != tt.want {
+				t.Errorf("ReadText() = %q, want %q", text.Content, tt.want)
+			}
+
+			omitted := 0
+			if text.Truncation != nil {
+				omitted = text.Truncation.OmittedLines
+			}
+			if omitted != tt.wantOmitted {
+				t.Errorf("OmittedLines = %d, want %d", omitted, tt.wantOmitted)
+			}
+		})
+	}
+}
+
+// skip 방식은 CheckSkip과 같이 원본 크기로 판단 (UTF-8로 변환하면 커지는 EUC-KR 파일)
+func TestFileParserSizeLimitSkipRawSize(t *testing.T) {
+	raw := bytes.Repeat([]byte("\xc7\xd1\xb1\xdb\n"), 189) // "한글\n" × 189 = 945바이트, UTF-8로는 1323바이트
+	path := filepath.Join(t.TempDir(), "euckr.txt")
+	if err := os.WriteFile(path, raw, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	fp := parser.NewFileParser()
+	fp.SetSizeLimit(parser.SizeLimit{MaxBytes: 1024, Strategy: parser.TruncateSkip})
+	skip, err := fp.CheckSkip(path)
+	if err != nil || skip != nil {
+		t.Fatalf("CheckSkip() = %+v, %v, want nil", skip, err)
+	}
+	text, err := fp.ReadText(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if text.Truncation != nil || text.Content != strings.Repeat("한글\n", 189) {
+		t.Errorf("ReadText() truncation = %+v, content %d바이트, want 전체 내용", text.Truncation, len(text.Content))
+	}
+}
+
+func TestDirectoryParserExplain(t *testing.T) {
+	tempDir := t.TempDir()
+	files := map[string]string{
+		".codeignore":       "# 로그\n*.log\n!keep.log\nbuild/\n",
+		"main.go":           "",
+		"keep.log":          "",
+		"debug.log":         "",
+		"build/output.go":   "",
+		"vendor/lib/lib.go": "",
+		".env":              "",
+		"README.md":         "",
+	}
+	for name, content := range files {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	codeIgnorePath := filepath.Join(tempDir, ".codeignore")
+	tests := []struct {
+		path string
+		want parser.Decision
+	}{
+		{"main.go", parser.Decision{Included: true, Stage: parser.StageType, Rule: "go"}},
+		{"keep.log", parser.Decision{Included: false, Stage: parser.StageType, Rule: "go"}},
+		{"debug.log", parser.Decision{Stage: parser.StageIgnore, Rule: "*.log", Source: codeIgnorePath, Line: 2}},
+		{"build/output.go", parser.Decision{Stage: parser.StageIgnore, Rule: "build/", Source: codeIgnorePath, Line: 4, Target: filepath.Join(tempDir, "build")}},
+		{"vendor/lib/lib.go", parser.Decision{Stage: parser.StageExclude, Rule: "vendor", Target: filepath.Join(tempDir, "vendor")}},
+		{".env", parser.Decision{Stage: parser.StageHidden, Rule: ".env"}},
+		{"README.md", parser.Decision{Stage: parser.StageType, Rule: "go"}},
+	}
+
+	p := parser.NewDirectoryParser([]string{"vendor"}, false, true)
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			path := filepath.Join(tempDir, tt.path)
+			got, err := p.Explain(tempDir, path, []string{"go"})
+			if err != nil {
+				t.Fatalf("Explain() error = %v", err)
+			}
+
+			want := tt.want
+			want.Path = path
+			if want.Target == "" {
+				want.Target = path
+			}
+			if got != want {
+				t.Errorf("Explain() = %+v, want %+v", got, want)
+			}
+		})
+	}
+
+	// 부정 패턴으로 포함된 경우 타입 필터가 없으면 그 패턴이 결정한 규칙
+	got, err := p.Explain(tempDir, filepath.Join(tempDir, "keep.log"), nil)
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if !got.Included || got.Rule != "!keep.log" || got.Line != 3 {
+		t.Errorf("Explain() = %+v, want !keep.log 규칙으로 포함", got)
+	}
+
+	// -include 패턴으로 포함된 경우 그 패턴이 결정한 규칙
+	p.SetIncludePatterns([]string{"*.md"})
+	got, err = p.Explain(tempDir, filepath.Join(tempDir, "README.md"), []string{"go"})
+	if err != nil {
+		t.Fatalf("Explain() error = %v", err)
+	}
+	if !got.Included || got.Stage != parser.StageInclude || got.Rule != "*.md" {
+		t.Errorf("Explain() = %+v, want *.md 패턴으로 포함", got)
+	}
+}
+
+func TestDirectoryParserExplainSymlinks(t *testing.T) {
+	tempDir := t.TempDir()
+	if err := os.MkdirAll(filepath.Join(tempDir, "lib"), 0755); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(tempDir, "lib", "lib.go"), []byte("package lib\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	for name, target := range map[string]string{"alias.go": "lib/lib.go", "shared": "lib"} {
+		if err := os.Symlink(target, filepath.Join(tempDir, name)); err != nil {
+			t.Skipf("심볼릭 링크를 만들 수 없음: %v", err)
+		}
+	}
+
+	tests := []struct {
+		mode     parser.SymlinkMode
+		path     string
+		included bool
+		stage    parser.FilterStage
+	}{
+		{parser.SymlinkFollowFiles, "alias.go", true, parser.StageType},
+		{parser.SymlinkFollowFiles, "shared", true, parser.StageSymlink}, // 내려가지 않고 링크로 기록
+		{parser.SymlinkFollowFiles, "shared/lib.go", false, parser.StageSymlink},
+		{parser.SymlinkFollow, "alias.go", true, parser.StageType},
+		{parser.SymlinkFollow, "shared", true, parser.StageSymlink},         // lib를 먼저 탐색하므로 링크로 기록
+		{parser.SymlinkFollow, "shared/lib.go", false, parser.StageSymlink}, // lib/lib.go로 이미 포함
+		{parser.SymlinkFollow, "lib/lib.go", true, parser.StageType},
+		{parser.SymlinkSkip, "alias.go", false, parser.StageSymlink},
+		{parser.SymlinkSkip, "shared/lib.go", false, parser.StageSymlink},
+		{parser.SymlinkRecord, "alias.go", true, parser.StageSymlink}, // 내용 대신 링크로 기록
+		{parser.SymlinkRecord, "shared", true, parser.StageSymlink},
+		{parser.SymlinkRecord, "shared/lib.go", false, parser.StageSymlink},
+	}
+	for _, tt := range tests {
+		p := parser.NewDirectoryParser(nil, false, false)
+		p.SetSymlinkMode(tt.mode)
+		got, err := p.Explain(tempDir, filepath.Join(tempDir, filepath.FromSlash(tt.path)), []string{"go"})
+		if err != nil {
+			t.Fatalf("Explain(%s) error = %v", tt.path, err)
+		}
+		if got.Included != tt.included || got.Stage != tt.stage {
+			t.Errorf("%s: Explain(%s) = %+v, want included=%v stage=%s", tt.mode, tt.path, got, tt.included, tt.stage)
+		}
+	}
+}
+
+func TestDirectoryParserHiddenPolicy(t *testing.T) {
+	tempDir := t.TempDir()
+	for _, name := range []string{
+		"main.go",
+		"__init__.py",
+		"_layouts/default.html",
+		".env",
+		".golangci.yml",
+		".github/workflows/ci.yml",
+		".vscode/settings.json",
+		".git/config",
+		"docs/.vitepress/config.js",
+		"src/.vitepress/config.js",
+	} {
+		path := filepath.Join(tempDir, name)
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte("test"), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name   string
+		policy parser.HiddenPolicy
+		want   []string
+	}{
+		{
+			name:   "기본값은 밑줄 파일 포함",
+			policy: parser.HiddenPolicy{},
+			want:   []string{"__init__.py", "_layouts/default.html", "main.go"},
+		},
+		{
+			name:   "밑줄 파일 숨김",
+			policy: parser.HiddenPolicy{HideUnderscore: true},
+			want:   []string{"main.go"},
+		},
+		{
+			name:   "허용 목록",
+			policy: parser.HiddenPolicy{Allow: []string{".github/", ".golangci.yml", "docs/.vitepress/"}},
+			want: []string{
+				".github/workflows/ci.yml", ".golangci.yml", "__init__.py", "_layouts/default.html",
+				"docs/.vitepress/config.js", "main.go",
+			},
+		},
+		{
+			name:   "숨김 파일 포함 (.git 제외)",
+			policy: parser.HiddenPolicy{IncludeDot: true},
+			want: []string{
+				".env", ".github/workflows/ci.yml", ".golangci.yml", ".vscode/settings.json", "__init__.py",
+				"_layouts/default.html", "docs/.vitepress/config.js", "main.go", "src/.vitepress/config.js",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := parser.NewDirectoryParser(nil, false, false)
+			p.SetHiddenPolicy(tt.policy)
+
+			files, err := p.Parse(tempDir)
+			if err != nil {
+				t.Fatalf("Parse() error = %v", err)
+			}
+
+			var got []string
+			for _, file := range files {
+				relPath, err := filepath.Rel(tempDir, file)
+				if err != nil {
+					t.Fatal(err)
+				}
+				got = append(got, filepath.ToSlash(relPath))
+			}
+			if strings.Join(got, "\n") != strings.Join(tt.want, "\n") {
+				t.Errorf("Parse() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
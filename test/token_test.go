@@ -0,0 +1,103 @@
+package test
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/kihyun1998/codemd/internal/file"
+	"github.com/kihyun1998/codemd/internal/token"
+)
+
+func TestTokenEstimator(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want int64
+	}{
+		{
+			name: "빈 문자열",
+			text: "",
+			want: 0,
+		},
+		{
+			name: "영문 단어",
+			text: "hello world",
+			want: 2,
+		},
+		{
+			name: "camelCase 식별자",
+			text: "getUserName",
+			want: 3,
+		},
+		{
+			name: "숫자",
+			text: "1234567",
+			want: 3,
+		},
+		{
+			name: "한글",
+			text: "안녕하세요",
+			want: 5,
+		},
+	}
+
+	counter := token.NewEstimator()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := counter.Count(tt.text); got != tt.want {
+				t.Errorf("Count(%q) = %v, want %v", tt.text, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenSplitter(t *testing.T) {
+	tempDir := t.TempDir()
+	testFile := filepath.Join(tempDir, "CODE.md")
+
+	var sections []file.Section
+	for _, name := range []string{"a.go", "b.go", "c.go", "d.go"} {
+		body := strings.Repeat("func example() { return value }\n", 20)
+		sections = append(sections, file.Section{
+			Title:   name,
+			Content: "## " + name + "\n```go\n" + body + "```\n",
+		})
+	}
+
+	const maxTokens = 300
+	counter := token.NewEstimator()
+	splitter := file.NewTokenSplitter(maxTokens, counter)
+	splitter.SetPartHeader(&file.PartHeader{ProjectName: "demo"})
+
+	writer, err := splitter.NewPartWriter(testFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, section := range sections {
+		if err := writer.WriteSection(section); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := writer.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	parts := writer.Parts()
+	if len(parts) < 2 {
+		t.Fatalf("토큰 기준으로 분할되지 않음: %d", len(parts))
+	}
+	for _, part := range parts {
+		data, err := os.ReadFile(part.Path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if part.Tokens > maxTokens {
+			t.Errorf("%s의 토큰 수가 제한을 초과함: %d", part.Path, part.Tokens)
+		}
+		if got := counter.Count(string(data)); got != part.Tokens {
+			t.Errorf("%s의 보고된 토큰 수가 다름. got %d, want %d", part.Path, part.Tokens, got)
+		}
+	}
+}